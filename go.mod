@@ -4,8 +4,10 @@ go 1.25
 
 require (
 	github.com/ethereum/go-ethereum v1.14.3
+	github.com/holiman/uint256 v1.2.4
 	github.com/spf13/cobra v1.10.1
 	github.com/zksync-sdk/zksync2-go v1.1.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -25,7 +27,6 @@ require (
 	github.com/go-ole/go-ole v1.3.0 // indirect
 	github.com/google/uuid v1.3.0 // indirect
 	github.com/gorilla/websocket v1.5.0 // indirect
-	github.com/holiman/uint256 v1.2.4 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/mmcloughlin/addchain v0.4.0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
@@ -4,19 +4,26 @@ import (
 	"bufio"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
 
 	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/fuzz"
+	"github.com/eth-error-tests/pkg/openrpc"
+	"github.com/eth-error-tests/pkg/recorder"
 	"github.com/eth-error-tests/pkg/runner"
+	"github.com/eth-error-tests/pkg/testcases"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
 	"github.com/spf13/cobra"
 )
 
-func Report(filename string) {
+// Report converts a test log into filename.csv with one row per response.
+func Report(filename string) error {
 	file, err := os.Open(filename)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -26,7 +33,7 @@ func Report(filename string) {
 
 	outputFile, err := os.Create(filename + ".csv")
 	if err != nil {
-		panic(err)
+		return err
 	}
 	defer func() {
 		if err := outputFile.Close(); err != nil {
@@ -34,47 +41,104 @@ func Report(filename string) {
 		}
 	}()
 
-	scanner := bufio.NewScanner(file)
-	writer := csv.NewWriter(outputFile)
-	defer writer.Flush()
+	return writeReport(file, outputFile)
+}
 
-	scenarioRegexp := regexp.MustCompile(`^Scenario: (.+?)\s+-\s+Request:`)
-	requestRegexp := regexp.MustCompile(`(?s)Request: (.+)`)
-	responseRegexp := regexp.MustCompile(`(?s)^Response: (.+)$`)
-	methodRegex := regexp.MustCompile(`"method":"([^"]+)"`)
-	err = writer.Write([]string{"Method", "scenario", "Response", "Request"})
-	if err != nil {
-		panic(err)
+var (
+	scenarioRegexp = regexp.MustCompile(`^Scenario: (.+?)\s+-\s+Request:`)
+	requestRegexp  = regexp.MustCompile(`(?s)Request: (.+)`)
+	responseRegexp = regexp.MustCompile(`(?s)^Response: (.+)$`)
+	methodRegex    = regexp.MustCompile(`"method":"([^"]+)"`)
+)
+
+// maxLogLine bounds the lines writeReport reads. Requests are truncated when logged, but responses are not and
+// can be large (e.g. echoed oversized data).
+const maxLogLine = 64 * 1024 * 1024
+
+func writeReport(log io.Reader, output io.Writer) error {
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLine)
+	writer := csv.NewWriter(output)
+
+	if err := writer.Write(append([]string{"Method", "scenario", "Response", "Request"}, checkColumns...)); err != nil {
+		return err
+	}
+	// The checks are logged on their own lines after a response, so its row is written once the next scenario
+	// starts or the log ends
+	var row []string
+	flush := func() error {
+		if row == nil {
+			return nil
+		}
+		err := writer.Write(row)
+		row = nil
+		return err
 	}
-	var scenario, request, response, method string
+	var scenario, request, method string
 	for scanner.Scan() {
 		line := scanner.Text()
+		if row != nil {
+			if column, value, ok := checkColumn(line); ok {
+				row[4+column] = value
+				continue
+			}
+		}
 		if matches := scenarioRegexp.FindStringSubmatch(line); len(matches) > 0 {
+			if err := flush(); err != nil {
+				return err
+			}
 			scenario = matches[1]
 			fmt.Println(scenario)
 		}
-		if matches := requestRegexp.FindStringSubmatch(line); len(matches) > 0 {
+		// Responses are matched first, they may echo a "Request: " fragment
+		if matches := responseRegexp.FindStringSubmatch(line); len(matches) > 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+			row = []string{method, scenario, matches[1], request, "", "", ""}
+		} else if matches := requestRegexp.FindStringSubmatch(line); len(matches) > 0 {
 			request = matches[1]
+			method = ""
 			if newmatches := methodRegex.FindStringSubmatch(request); len(newmatches) > 0 {
 				method = newmatches[1]
 			}
-		} else if matches := responseRegexp.FindStringSubmatch(line); len(matches) > 0 {
-			response = matches[1]
-			err = writer.Write([]string{method, scenario, response, request})
-			if err != nil {
-				panic(err)
-			}
 		}
 	}
-
 	if err := scanner.Err(); err != nil {
-		fmt.Println("Error:", err)
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// checkColumns are the checks logged on their own "<check>: <result>" lines after a response.
+var checkColumns = []string{"Schema", "Conformance", "Error data"}
+
+// checkColumn returns the index in checkColumns and the result of a check line.
+func checkColumn(line string) (int, string, bool) {
+	for i, check := range checkColumns {
+		if value, ok := strings.CutPrefix(line, check+": "); ok {
+			return i, value, true
+		}
 	}
+	return 0, "", false
 }
 
 var (
-	env   string
-	tests string
+	env          string
+	tests        string
+	recordDir    string
+	replayDir    string
+	scenariosDir string
+	fuzzOut      string
+	diffMutate   bool
+	specFile     string
+	noSchema     bool
+	parallel     int
 )
 
 var rootCmd = &cobra.Command{
@@ -86,7 +150,17 @@ var rootCmd = &cobra.Command{
   eth-err-tests --env geth-local
 
   # Run specific tests on zkEVM
-  eth-err-tests --env zkevm --tests eth_call,eth_estimateGas`,
+  eth-err-tests --env zkevm --tests eth_call,eth_estimateGas
+
+  # Run scenario files alongside the built-in tests
+  eth-err-tests --env geth-local --scenarios scenarios
+
+  # Run the scenarios of each test 8 at a time, sending transactions from 8 derived accounts
+  eth-err-tests --env sepolia --parallel 8
+
+  # Record a sepolia run and replay it later without network access
+  eth-err-tests --env sepolia --record bundles/sepolia
+  eth-err-tests --env sepolia --replay bundles/sepolia`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg, err := config.GetConfig(env)
 		if err != nil {
@@ -94,6 +168,58 @@ var rootCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if parallel < 1 {
+			fmt.Printf("Error: --parallel must be at least 1, got %d\n", parallel)
+			os.Exit(1)
+		}
+		cfg.Parallel = parallel
+
+		if !noSchema {
+			cfg.Spec = openrpc.Default()
+			if specFile != "" {
+				if cfg.Spec, err = openrpc.Load(specFile); err != nil {
+					fmt.Printf("Error loading OpenRPC spec: %v\n", err)
+					os.Exit(1)
+				}
+			}
+		}
+
+		if scenariosDir != "" {
+			fileTestCases, err := testcases.LoadScenarioFiles(scenariosDir)
+			if err != nil {
+				fmt.Printf("Error loading scenario files: %v\n", err)
+				os.Exit(1)
+			}
+			testcases.RegisterTestCases(fileTestCases...)
+			fmt.Printf("Loaded %d scenario file(s) from: %s\n", len(fileTestCases), scenariosDir)
+		}
+
+		if replayDir != "" {
+			replayer, err := recorder.LoadReplay(replayDir)
+			if err != nil {
+				fmt.Printf("Error loading replay: %v\n", err)
+				os.Exit(1)
+			}
+			defer replayer.Close()
+
+			cfg, err = replayer.Configure(cfg)
+			if err != nil {
+				fmt.Printf("Error starting replay: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Replaying session from: %s\n", replayDir)
+		}
+
+		var rec *recorder.Recorder
+		if recordDir != "" {
+			rec, err = recorder.Install(recordDir)
+			if err != nil {
+				fmt.Printf("Error starting recorder: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Recording session to: %s\n", recordDir)
+		}
+
 		fmt.Printf("Testing Network: %s\n", cfg.Network)
 		fmt.Printf("RPC URL: %s\n", cfg.Url)
 		fmt.Println("=======================================================")
@@ -114,8 +240,19 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
-		if err := testRunner.RunWithAutoDeployment(testNames); err != nil {
-			fmt.Printf("Error running tests: %v\n", err)
+		runErr := testRunner.RunWithAutoDeployment(testNames)
+
+		if rec != nil {
+			if err := rec.SaveSession(recorder.NewSession(testRunner.Config())); err != nil {
+				fmt.Printf("Error saving recorded session: %v\n", err)
+			}
+			if err := rec.Close(); err != nil {
+				fmt.Printf("Error closing recorder: %v\n", err)
+			}
+		}
+
+		if runErr != nil {
+			fmt.Printf("Error running tests: %v\n", runErr)
 			os.Exit(1)
 		}
 	},
@@ -129,20 +266,189 @@ var reportCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		logFile := args[0]
 		fmt.Println("Generating report from log file:", logFile)
-		Report(logFile)
+		if err := Report(logFile); err != nil {
+			fmt.Printf("Error generating report: %v\n", err)
+			os.Exit(1)
+		}
 	},
 }
 
+var fuzzCmd = &cobra.Command{
+	Use:  "fuzz",
+	Long: "Mutate the params of each test case's proper requests and record every distinct response shape",
+	Example: `eth-err-tests fuzz --env geth-local --out reports/geth-local-fuzz.json
+
+  # Fuzz specific test cases
+  eth-err-tests fuzz --env besu-local --tests eth_call,eth_getBalance`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runFuzz(); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// runFuzz fuzzes the selected test cases on env. Errors are returned rather than exiting, so the deferred
+// cleanup stops the local node before the command exits with a non-zero status.
+func runFuzz() error {
+	cfg, err := config.GetConfig(env)
+	if err != nil {
+		return fmt.Errorf("invalid environment '%s': %w", env, err)
+	}
+
+	testRunner, err := runner.NewTestRunner(cfg)
+	if err != nil {
+		return fmt.Errorf("creating test runner: %w", err)
+	}
+	defer testRunner.Cleanup()
+
+	if err := testRunner.Setup(); err != nil {
+		return fmt.Errorf("setting up: %w", err)
+	}
+
+	testCases, err := selectTestCases(tests)
+	if err != nil {
+		return err
+	}
+
+	report := fuzz.New(testRunner.Config()).Run(testCases)
+	report.PrintSummary()
+
+	if fuzzOut != "" {
+		if err := report.WriteJSON(fuzzOut); err != nil {
+			return fmt.Errorf("writing fuzz report: %w", err)
+		}
+		fmt.Println("Fuzz report written to:", fuzzOut)
+	}
+	return nil
+}
+
+var diffCmd = &cobra.Command{
+	Use:  "diff",
+	Long: "Send the same requests to two or more clients in lockstep and report only the divergences in success vs error, error code or error category",
+	Example: `eth-err-tests diff --env geth-local,besu-local
+
+  # Compare the fuzz mutations of specific test cases
+  eth-err-tests diff --env geth-local,besu-local --mutate --tests eth_call --out reports/geth-besu-diff.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runDiff(); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// runDiff compares the selected test cases across the networks of env. Like runFuzz it returns its errors, so
+// the local nodes are stopped before the command exits with a non-zero status.
+func runDiff() error {
+	envs := strings.Split(env, ",")
+	if len(envs) < 2 {
+		return fmt.Errorf("--env needs at least two comma-separated networks")
+	}
+
+	var runners []*runner.TestRunner
+	defer func() {
+		for _, testRunner := range runners {
+			testRunner.Cleanup()
+		}
+	}()
+
+	var endpoints []fuzz.Endpoint
+	for i, name := range envs {
+		cfg, err := config.GetConfig(strings.TrimSpace(name))
+		if err != nil {
+			return fmt.Errorf("invalid environment '%s': %w", name, err)
+		}
+		// Local nodes after the first one are published on other ports so the containers can run side by side
+		if cfg.IsLocalNode() && i > 0 {
+			if cfg, err = cfg.WithPortOffset(100 * i); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		testRunner, err := runner.NewTestRunner(cfg)
+		if err != nil {
+			return fmt.Errorf("creating test runner: %w", err)
+		}
+		runners = append(runners, testRunner)
+
+		if err := testRunner.Setup(); err != nil {
+			return fmt.Errorf("setting up %s: %w", cfg.Network, err)
+		}
+		endpoints = append(endpoints, fuzz.Endpoint{Name: cfg.Network, Cfg: testRunner.Config()})
+	}
+
+	testCases, err := selectTestCases(tests)
+	if err != nil {
+		return err
+	}
+
+	report := fuzz.NewDiffer(endpoints, diffMutate).Run(testCases)
+	report.PrintSummary()
+
+	if fuzzOut != "" {
+		if err := report.WriteJSON(fuzzOut); err != nil {
+			return fmt.Errorf("writing differential report: %w", err)
+		}
+		fmt.Println("Differential report written to:", fuzzOut)
+	}
+	return nil
+}
+
+// selectTestCases returns the comma-separated test cases, or all of them when names is empty.
+func selectTestCases(names string) ([]pkgTypes.TestCase, error) {
+	if names == "" {
+		return testcases.GetAllTestCases(), nil
+	}
+
+	var testCases []pkgTypes.TestCase
+	for _, name := range strings.Split(names, ",") {
+		testCase := testcases.GetTestCaseByName(strings.TrimSpace(name))
+		if testCase == nil {
+			return nil, fmt.Errorf("test case '%s' not found", name)
+		}
+		testCases = append(testCases, testCase)
+	}
+	return testCases, nil
+}
+
 func init() {
 	// Root command
 	rootCmd.Flags().StringVarP(&env, "env", "e", "", "Network/client to test (required)")
 	rootCmd.Flags().StringVarP(&tests, "tests", "t", "", "Comma-separated list of tests to run (e.g., eth_getBalance,eth_getCode,eth_call,eth_estimateGas,eth_sendRawTransaction)")
+	rootCmd.Flags().StringVar(&recordDir, "record", "", "Record every HTTP request/response of the run into this directory")
+	rootCmd.Flags().StringVar(&scenariosDir, "scenarios", "", "Directory of YAML/JSON scenario files to load as additional test cases")
+	rootCmd.Flags().StringVar(&replayDir, "replay", "", "Replay a session recorded with --record instead of contacting the network")
+	rootCmd.Flags().StringVar(&specFile, "spec", "", "OpenRPC spec to validate successful results against, e.g. a refs-openrpc.json build of execution-apis (default: embedded hand-written approximation)")
+	rootCmd.Flags().BoolVar(&noSchema, "no-schema", false, "Skip the validation of successful results against the result schemas")
+	rootCmd.Flags().IntVar(&parallel, "parallel", 1, "Number of scenarios run concurrently, transaction scenarios are sent from as many derived sender accounts")
 	if err := rootCmd.MarkFlagRequired("env"); err != nil {
 		panic(err)
 	}
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+	rootCmd.MarkFlagsMutuallyExclusive("spec", "no-schema")
 
 	// report command
 	rootCmd.AddCommand(reportCmd)
+
+	// fuzz command
+	fuzzCmd.Flags().StringVarP(&env, "env", "e", "", "Network/client to fuzz (required)")
+	fuzzCmd.Flags().StringVarP(&tests, "tests", "t", "", "Comma-separated list of test cases whose proper requests are mutated (default: all)")
+	fuzzCmd.Flags().StringVarP(&fuzzOut, "out", "o", "", "Write the distinct response shapes as JSON to this file")
+	if err := fuzzCmd.MarkFlagRequired("env"); err != nil {
+		panic(err)
+	}
+	rootCmd.AddCommand(fuzzCmd)
+
+	// diff command
+	diffCmd.Flags().StringVarP(&env, "env", "e", "", "Comma-separated networks/clients to compare, the first one is the reference (required)")
+	diffCmd.Flags().StringVarP(&tests, "tests", "t", "", "Comma-separated list of test cases to compare (default: all)")
+	diffCmd.Flags().BoolVar(&diffMutate, "mutate", false, "Compare the fuzz mutations of the proper requests instead of the test case scenarios")
+	diffCmd.Flags().StringVarP(&fuzzOut, "out", "o", "", "Write the divergences as JSON to this file")
+	if err := diffCmd.MarkFlagRequired("env"); err != nil {
+		panic(err)
+	}
+	rootCmd.AddCommand(diffCmd)
 }
 
 func main() {
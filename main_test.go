@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	log := `Running Test: eth_getBalance
+Scenario: Valid account balance request  - Request: {"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x01","latest"]}
+Response: [{"id":1,"jsonrpc":"2.0","result":"0x0"}]
+Schema: PASS
+Conformance: PASS
+Scenario: Invalid account format  - Request: {"jsonrpc":"2.0","id":2,"method":"eth_getBalance","params":["0x1234","latest"]}
+Response: [{"error":{"code":-32602,"message":"invalid argument 0"},"id":2,"jsonrpc":"1.0"}], Expected code -32602: PASS
+Conformance: FAIL ([0].jsonrpc is "1.0", want "2.0")
+Error data: absent
+Scenario: NONCE_TOO_LOW  - Request: [{"jsonrpc":"2.0","id":3,"method":"eth_sendRawTransaction","params":["0x02"]}]
+Error: connection refused
+Scenario: NONCE_TOO_HIGH  - Request: [{"jsonrpc":"2.0","id":4,"method":"eth_sendRawTransaction","params":["0x03"]}]
+Response: [{"id":4,"jsonrpc":"2.0","result":"0xaa"}]
+`
+	filename := filepath.Join(t.TempDir(), "report.log")
+	if err := os.WriteFile(filename, []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	err = Report(filename)
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := os.Open(filename + ".csv")
+	if err != nil {
+		t.Fatalf("report was not written: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	want := [][]string{
+		{"Method", "scenario", "Response", "Request", "Schema", "Conformance", "Error data"},
+		{
+			"eth_getBalance",
+			"Valid account balance request",
+			`[{"id":1,"jsonrpc":"2.0","result":"0x0"}]`,
+			`{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x01","latest"]}`,
+			"PASS",
+			"PASS",
+			"",
+		},
+		{
+			"eth_getBalance",
+			"Invalid account format",
+			`[{"error":{"code":-32602,"message":"invalid argument 0"},"id":2,"jsonrpc":"1.0"}], Expected code -32602: PASS`,
+			`{"jsonrpc":"2.0","id":2,"method":"eth_getBalance","params":["0x1234","latest"]}`,
+			"",
+			`FAIL ([0].jsonrpc is "1.0", want "2.0")`,
+			"absent",
+		},
+		{
+			"eth_sendRawTransaction",
+			"NONCE_TOO_HIGH",
+			`[{"id":4,"jsonrpc":"2.0","result":"0xaa"}]`,
+			`[{"jsonrpc":"2.0","id":4,"method":"eth_sendRawTransaction","params":["0x03"]}]`,
+			"",
+			"",
+			"",
+		},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("unexpected report rows:\n got %q\nwant %q", rows, want)
+	}
+}
+
+func TestReportMissingFile(t *testing.T) {
+	if err := Report(filepath.Join(t.TempDir(), "missing.log")); err == nil {
+		t.Error("expected an error for a missing log file")
+	}
+}
+
+func FuzzReport(f *testing.F) {
+	f.Add("Scenario: Valid  - Request: {\"method\":\"eth_getBalance\"}\nResponse: [{\"result\":\"0x0\"}]\n")
+	f.Add("Response: without request\nScenario: x  - Request: [{\"method\":\"eth_call\"}]\nError: refused\n")
+	f.Add("Scenario: echo  - Request: {}\nResponse: {\"error\":{\"message\":\"bad Request: \\\"x\\\"\"}}\r\n")
+	f.Add("Scenario: \"quoted\", comma  - Request: {\"method\":\"a\"}\nResponse: \"a\",\"b\"\n" + "Response: " + strings.Repeat("0", 70_000) + "\n")
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Cleanup(func() { devNull.Close() })
+
+	f.Fuzz(func(t *testing.T, log string) {
+		stdout := os.Stdout
+		os.Stdout = devNull
+		var output bytes.Buffer
+		err := writeReport(strings.NewReader(log), &output)
+		os.Stdout = stdout
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var responses []string
+		scanner := bufio.NewScanner(strings.NewReader(log))
+		scanner.Buffer(nil, maxLogLine)
+		for scanner.Scan() {
+			if matches := responseRegexp.FindStringSubmatch(scanner.Text()); len(matches) > 0 {
+				responses = append(responses, matches[1])
+			}
+		}
+
+		rows, err := csv.NewReader(&output).ReadAll()
+		if err != nil {
+			t.Fatalf("report is not valid CSV: %v", err)
+		}
+		if len(rows) != len(responses)+1 {
+			t.Fatalf("%d rows for %d responses", len(rows)-1, len(responses))
+		}
+		for i, response := range responses {
+			// CSV readers normalize \r\n inside quoted fields
+			if want := strings.ReplaceAll(response, "\r\n", "\n"); rows[i+1][2] != want {
+				t.Fatalf("row %d response = %q, want %q", i+1, rows[i+1][2], want)
+			}
+		}
+	})
+}
@@ -2,8 +2,12 @@ package config
 
 import (
 	"errors"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
 
+	"github.com/eth-error-tests/pkg/openrpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -14,10 +18,18 @@ type Config struct {
 	From              string // Will be updated with derived address from PrivateKey
 	ToContract        string // Will be updated with deployed contract address
 	DeployedContracts map[string]common.Address
+	DeploymentTxs     map[string]common.Hash // contract name -> deployment tx hash
 	PrivateKey        string
 	ChainID           int64
 	InvalidContract   string
-	LocalNodeType     string // Type of local node: "besu", "geth", "reth", etc.
+	LocalNodeType     string        // Type of local node: "besu", "geth", "reth", etc.
+	DevAccount        string        // Unlocked node account, discovered when a local node is started
+	EngineUrl         string        // Authenticated Engine API endpoint, only served by local nodes
+	JWTSecret         string        // Hex encoded Engine API secret, generated when a local node is started
+	Replaying         bool          // Traffic is served from a recorded session, so no local node is started
+	Spec              *openrpc.Spec // Validates the shape of successful results, nil disables the check
+	Parallel          int           // Scenarios run concurrently, 1 or less runs them serially
+	Senders           []string      // Funded private keys transaction scenarios are spread over when running in parallel
 }
 
 var (
@@ -58,6 +70,7 @@ var (
 		ChainID:         1337,
 		InvalidContract: "0x0baEAd25fe0346B76C73e84c083bb503c14309F1",
 		LocalNodeType:   "besu",
+		EngineUrl:       "http://localhost:8551",
 	}
 
 	// Local Geth node configuration
@@ -68,6 +81,7 @@ var (
 		ChainID:         1337,
 		InvalidContract: "0x0baEAd25fe0346B76C73e84c083bb503c14309F1",
 		LocalNodeType:   "geth",
+		// No EngineUrl: geth's dev mode does not serve the Engine API
 	}
 
 	// Local Reth node configuration
@@ -78,6 +92,7 @@ var (
 		ChainID:         1337,
 		InvalidContract: "0x0baEAd25fe0346B76C73e84c083bb503c14309F1",
 		LocalNodeType:   "reth",
+		EngineUrl:       "http://localhost:8551",
 	}
 
 	// Local Nethermind node configuration
@@ -88,6 +103,7 @@ var (
 		ChainID:         1337,
 		InvalidContract: "0x0baEAd25fe0346B76C73e84c083bb503c14309F1",
 		LocalNodeType:   "nethermind",
+		EngineUrl:       "http://localhost:8551",
 	}
 
 	// Local Erigon node configuration
@@ -98,6 +114,7 @@ var (
 		ChainID:         1337,
 		InvalidContract: "0x0baEAd25fe0346B76C73e84c083bb503c14309F1",
 		LocalNodeType:   "erigon",
+		EngineUrl:       "http://localhost:8551",
 	}
 )
 
@@ -127,3 +144,33 @@ func GetConfig(env string) (Config, error) {
 func (c *Config) IsLocalNode() bool {
 	return c.LocalNodeType != ""
 }
+
+// WithPortOffset returns a copy of c with offset added to the ports of Url and EngineUrl, so a second
+// local node can run next to the first one.
+func (c Config) WithPortOffset(offset int) (Config, error) {
+	var err error
+	if c.Url, err = offsetPort(c.Url, offset); err != nil {
+		return c, err
+	}
+	if c.EngineUrl, err = offsetPort(c.EngineUrl, offset); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+func offsetPort(rawUrl string, offset int) (string, error) {
+	if rawUrl == "" || offset == 0 {
+		return rawUrl, nil
+	}
+
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	port, err := strconv.Atoi(parsed.Port())
+	if err != nil {
+		return "", errors.New("url has no numeric port: " + rawUrl)
+	}
+	parsed.Host = net.JoinHostPort(parsed.Hostname(), strconv.Itoa(port+offset))
+	return parsed.String(), nil
+}
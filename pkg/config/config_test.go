@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestWithPortOffset(t *testing.T) {
+	cfg, err := GetConfig("besu-local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offset, err := cfg.WithPortOffset(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset.Url != "http://localhost:8645" || offset.EngineUrl != "http://localhost:8651" {
+		t.Errorf("urls = %s, %s", offset.Url, offset.EngineUrl)
+	}
+	if cfg.Url != "http://localhost:8545" {
+		t.Errorf("original config modified: %s", cfg.Url)
+	}
+
+	if _, err := (Config{Url: "https://rpc.example.org"}).WithPortOffset(100); err == nil {
+		t.Error("expected an error for a url without port")
+	}
+}
@@ -136,8 +136,9 @@ func (d *Deployer) DeployContract(contractName contract.Name) (*pkgTypes.Deploym
 	}, nil
 }
 
-func (d *Deployer) DeploySpecificContracts(contractNames []contract.Name) (map[string]common.Address, []error) {
+func (d *Deployer) DeploySpecificContracts(contractNames []contract.Name) (map[string]common.Address, map[string]common.Hash, []error) {
 	deployedContracts := make(map[string]common.Address)
+	deploymentTxs := make(map[string]common.Hash)
 	var errors []error
 
 	for _, name := range contractNames {
@@ -150,12 +151,13 @@ func (d *Deployer) DeploySpecificContracts(contractNames []contract.Name) (map[s
 
 		if result.Success {
 			deployedContracts[string(name)] = result.ContractAddress
+			deploymentTxs[string(name)] = result.TxHash
 		} else {
 			errors = append(errors, result.Error)
 		}
 	}
 
-	return deployedContracts, errors
+	return deployedContracts, deploymentTxs, errors
 }
 
 func (d *Deployer) Close() {
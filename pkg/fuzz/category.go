@@ -0,0 +1,65 @@
+package fuzz
+
+import "strings"
+
+// messageCategories maps message fragments to error categories. Clients word the same condition
+// differently, so several fragments may map to one category. Order matters: the first match wins.
+var messageCategories = []struct {
+	fragment string
+	category string
+}{
+	{"nonce too low", "NONCE_TOO_LOW"},
+	{"nonce too high", "NONCE_TOO_HIGH"},
+	{"nonce too distant", "NONCE_TOO_HIGH"},
+	{"insufficient funds", "INSUFFICIENT_FUNDS"},
+	{"upfront cost exceeds", "INSUFFICIENT_FUNDS"},
+	{"intrinsic gas too low", "GAS_TOO_LOW"},
+	{"intrinsic gas exceeds", "GAS_TOO_LOW"},
+	{"already known", "ALREADY_KNOWN"},
+	{"known transaction", "ALREADY_KNOWN"},
+	{"replacement transaction underpriced", "REPLACEMENT_UNDERPRICED"},
+	{"replacement_underpriced", "REPLACEMENT_UNDERPRICED"},
+	{"max priority fee per gas higher than max fee", "TIP_ABOVE_FEE_CAP"},
+	{"exceeds the configured cap", "FEE_CAP_EXCEEDED"},
+	{"tx fee", "FEE_CAP_EXCEEDED"},
+	{"exceeds block gas limit", "BLOCK_GAS_LIMIT_EXCEEDED"},
+	{"gas limit reached", "BLOCK_GAS_LIMIT_EXCEEDED"},
+	{"oversized data", "OVERSIZED_DATA"},
+	{"underpriced", "GAS_PRICE_TOO_LOW"},
+	{"below minimum", "GAS_PRICE_TOO_LOW"},
+	{"execution reverted", "EXECUTION_REVERTED"},
+	{"invalid opcode", "EXECUTION_REVERTED"},
+	{"out of gas", "OUT_OF_GAS"},
+	{"method not found", "METHOD_NOT_FOUND"},
+	{"does not exist/is not available", "METHOD_NOT_FOUND"},
+	{"not found", "NOT_FOUND"},
+	{"unknown block", "NOT_FOUND"},
+	{"invalid argument", "INVALID_PARAMS"},
+	{"invalid params", "INVALID_PARAMS"},
+	{"missing value", "INVALID_PARAMS"},
+	{"cannot unmarshal", "INVALID_PARAMS"},
+}
+
+// codeCategories are the fallbacks for messages that match no fragment.
+var codeCategories = map[int]string{
+	-32700: "PARSE_ERROR",
+	-32600: "INVALID_REQUEST",
+	-32601: "METHOD_NOT_FOUND",
+	-32602: "INVALID_PARAMS",
+	-32603: "INTERNAL_ERROR",
+	3:      "EXECUTION_REVERTED",
+}
+
+// Categorize assigns a client-independent category to an error.
+func Categorize(code int, message string) string {
+	lower := strings.ToLower(message)
+	for _, mc := range messageCategories {
+		if strings.Contains(lower, mc.fragment) {
+			return mc.category
+		}
+	}
+	if category, ok := codeCategories[code]; ok {
+		return category
+	}
+	return "OTHER"
+}
@@ -0,0 +1,298 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// Divergence reasons, from the most to the least significant
+const (
+	ReasonSuccessVsError = "success vs error"
+	ReasonResponseFormat = "response format"
+	ReasonErrorCode      = "error code"
+	ReasonErrorCategory  = "error category"
+)
+
+// Endpoint is one client taking part in a differential run. Its requests are rendered from Cfg, so the
+// contract addresses, accounts and block or transaction hashes in them are those of its own chain.
+type Endpoint struct {
+	Name string
+	Cfg  config.Config
+}
+
+// Outcome is the response of one endpoint to a request.
+type Outcome struct {
+	Endpoint string `json:"endpoint"`
+	Request  string `json:"request,omitempty"` // Set when it differs from the first endpoint's request
+	Shape    Shape  `json:"shape"`
+	Response string `json:"response"`
+}
+
+// Divergence is a request the endpoints answered differently.
+type Divergence struct {
+	TestCase string    `json:"testCase"`
+	Scenario string    `json:"scenario"`
+	Method   string    `json:"method"`
+	Request  string    `json:"request"`
+	Reason   string    `json:"reason"`
+	Outcomes []Outcome `json:"outcomes"`
+}
+
+type DiffReport struct {
+	Endpoints   []string      `json:"endpoints"`
+	Requests    int           `json:"requests"`
+	Skipped     int           `json:"skipped"` // Scenarios some endpoint did not generate
+	Divergences []*Divergence `json:"divergences"`
+}
+
+// Differ sends every scenario to all endpoints in lockstep and keeps only the divergences.
+type Differ struct {
+	endpoints []Endpoint
+	mutate    bool
+}
+
+// NewDiffer compares endpoints scenario by scenario, each rendering the scenario from its own config. With
+// mutate set the scenarios are the fuzz mutations of each test case's proper requests, otherwise the test
+// cases' own scenarios.
+func NewDiffer(endpoints []Endpoint, mutate bool) *Differ {
+	return &Differ{endpoints: endpoints, mutate: mutate}
+}
+
+func (d *Differ) Run(testCases []pkgTypes.TestCase) *DiffReport {
+	report := &DiffReport{}
+	for _, endpoint := range d.endpoints {
+		report.Endpoints = append(report.Endpoints, endpoint.Name)
+	}
+
+	for _, testCase := range testCases {
+		for _, scenario := range d.scenarios(testCase) {
+			if scenario == nil {
+				report.Skipped++
+				continue
+			}
+			report.Requests++
+			if divergence := d.compare(testCase.Name(), scenario); divergence != nil {
+				report.Divergences = append(report.Divergences, divergence)
+				divergence.print()
+			}
+		}
+	}
+
+	return report
+}
+
+type diffRequest struct {
+	desc    string
+	request pkgTypes.JsonRpcRequest
+}
+
+// scenarios renders the test case for every endpoint and pairs the requests by scenario, in the first
+// endpoint's order. Scenarios are keyed by description and occurrence, since mutation descriptions repeat
+// across seeds. A scenario missing on some endpoint (e.g. one needing a head block that could not be looked
+// up) is nil.
+func (d *Differ) scenarios(testCase pkgTypes.TestCase) [][]diffRequest {
+	type key struct {
+		desc       string
+		occurrence int
+	}
+	keyed := func(requests []diffRequest) ([]key, map[key]diffRequest) {
+		keys := make([]key, 0, len(requests))
+		byKey := make(map[key]diffRequest, len(requests))
+		seen := make(map[string]int)
+		for _, request := range requests {
+			k := key{request.desc, seen[request.desc]}
+			seen[request.desc]++
+			keys = append(keys, k)
+			byKey[k] = request
+		}
+		return keys, byKey
+	}
+
+	rendered := make([]map[key]diffRequest, len(d.endpoints))
+	var order []key
+	for i, endpoint := range d.endpoints {
+		keys, byKey := keyed(d.requests(testCase, endpoint.Cfg))
+		if i == 0 {
+			order = keys
+		}
+		rendered[i] = byKey
+	}
+
+	scenarios := make([][]diffRequest, 0, len(order))
+	for _, k := range order {
+		scenario := make([]diffRequest, 0, len(d.endpoints))
+		for _, byKey := range rendered {
+			request, ok := byKey[k]
+			if !ok {
+				scenario = nil
+				break
+			}
+			scenario = append(scenario, request)
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios
+}
+
+func (d *Differ) requests(testCase pkgTypes.TestCase, cfg config.Config) []diffRequest {
+	var requests []diffRequest
+
+	if d.mutate {
+		for _, seed := range Seeds(testCase, cfg) {
+			params, err := normalizeParams(seed.Params)
+			if err != nil {
+				fmt.Printf("Skipping %s seed %s: %v\n", testCase.Name(), seed.Method, err)
+				continue
+			}
+			for _, mutation := range Mutate(params) {
+				request := seed
+				request.Params = mutation.Params
+				requests = append(requests, diffRequest{desc: "Fuzz " + mutation.Desc, request: request})
+			}
+		}
+		return requests
+	}
+
+	for _, meta := range testCase.GetRequests(cfg) {
+		// Engine API requests need the endpoint's own JWT and URL
+		if len(meta.Headers) > 0 {
+			continue
+		}
+		requests = append(requests, diffRequest{desc: meta.Desc, request: meta.JsonRpcRequest})
+	}
+	return requests
+}
+
+// compare sends every endpoint its own rendering of the scenario.
+func (d *Differ) compare(testCase string, scenario []diffRequest) *Divergence {
+	requests := make([]string, len(scenario))
+	for i, req := range scenario {
+		r, err := json.Marshal(req.request)
+		if err != nil {
+			fmt.Printf("Skipping %s scenario %q: %v\n", testCase, req.desc, err)
+			return nil
+		}
+		requests[i] = string(r)
+	}
+
+	outcomes := make([]Outcome, len(d.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range d.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint Endpoint) {
+			defer wg.Done()
+			outcome := Outcome{Endpoint: endpoint.Name}
+			if requests[i] != requests[0] {
+				outcome.Request = requests[i]
+			}
+			response, err := jsonrpc.SendRawJSONRPCRequest(endpoint.Cfg.Url, []pkgTypes.JsonRpcRequest{scenario[i].request})
+			if err != nil {
+				outcome.Shape = Shape{Kind: "invalid", Message: "transport error"}
+				outcome.Response = err.Error()
+			} else {
+				outcome.Shape = Classify(response)
+				outcome.Response = compact(response)
+			}
+			outcomes[i] = outcome
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	reason := divergenceReason(outcomes)
+	if reason == "" {
+		return nil
+	}
+
+	return &Divergence{
+		TestCase: testCase,
+		Scenario: scenario[0].desc,
+		Method:   scenario[0].request.Method,
+		Request:  requests[0],
+		Reason:   reason,
+		Outcomes: outcomes,
+	}
+}
+
+// divergenceReason compares every endpoint with the first one, ignoring result values and error wording,
+// and returns the most significant difference found.
+func divergenceReason(outcomes []Outcome) string {
+	rank := map[string]int{"": 0, ReasonErrorCategory: 1, ReasonErrorCode: 2, ReasonResponseFormat: 3, ReasonSuccessVsError: 4}
+
+	reason := ""
+	for _, outcome := range outcomes[1:] {
+		if r := pairReason(outcomes[0].Shape, outcome.Shape); rank[r] > rank[reason] {
+			reason = r
+		}
+	}
+	return reason
+}
+
+func pairReason(a, b Shape) string {
+	switch {
+	case a.Kind != b.Kind && (a.Kind == "result" || b.Kind == "result"):
+		return ReasonSuccessVsError
+	case a.Kind != b.Kind:
+		return ReasonResponseFormat
+	case a.Kind != "error":
+		return ""
+	case a.Code != b.Code:
+		return ReasonErrorCode
+	case a.Category != b.Category:
+		return ReasonErrorCategory
+	default:
+		return ""
+	}
+}
+
+func (d *Divergence) print() {
+	fmt.Printf("Divergence (%s): %s  - Request: %s\n", d.Reason, d.Scenario, truncate(d.Request, 1000))
+	for _, outcome := range d.Outcomes {
+		if outcome.Request != "" {
+			fmt.Printf("  %s request: %s\n", outcome.Endpoint, truncate(outcome.Request, 1000))
+		}
+		fmt.Printf("  %s [%s]: %s\n", outcome.Endpoint, outcomeLabel(outcome.Shape), truncate(outcome.Response, 1000))
+	}
+}
+
+func outcomeLabel(shape Shape) string {
+	if shape.Kind == "error" {
+		return fmt.Sprintf("%d %s", shape.Code, shape.Category)
+	}
+	return shape.Kind
+}
+
+// PrintSummary prints the number of divergences per reason and method.
+func (r *DiffReport) PrintSummary() {
+	fmt.Println("=======================================================")
+	fmt.Printf("Differential summary for %s: %d requests, %d divergences, %d skipped\n",
+		strings.Join(r.Endpoints, " vs "), r.Requests, len(r.Divergences), r.Skipped)
+
+	var keys []string
+	counts := make(map[string]int)
+	for _, divergence := range r.Divergences {
+		key := fmt.Sprintf("%-18s %s", divergence.Reason, divergence.Method)
+		if counts[key] == 0 {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	for _, key := range keys {
+		fmt.Printf("  %-60s %4d\n", key, counts[key])
+	}
+}
+
+// WriteJSON writes the report to path.
+func (r *DiffReport) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
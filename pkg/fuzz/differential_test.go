@@ -0,0 +1,198 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+func TestCategorize(t *testing.T) {
+	tests := []struct {
+		code    int
+		message string
+		want    string
+	}{
+		{-32000, "nonce too low: next nonce 5, tx nonce 1", "NONCE_TOO_LOW"},
+		{-32001, "Nonce too distant from current sender nonce", "NONCE_TOO_HIGH"},
+		{-32000, "insufficient funds for gas * price + value", "INSUFFICIENT_FUNDS"},
+		{-32004, "Upfront cost exceeds account balance", "INSUFFICIENT_FUNDS"},
+		{-32601, "the method eth_foo does not exist/is not available", "METHOD_NOT_FOUND"},
+		{-32000, "header not found", "NOT_FOUND"},
+		{-32602, "missing value for required argument 1", "INVALID_PARAMS"},
+		{-32603, "something unexpected", "INTERNAL_ERROR"},
+		{-39999, "something unexpected", "OTHER"},
+	}
+
+	for _, tt := range tests {
+		if got := Categorize(tt.code, tt.message); got != tt.want {
+			t.Errorf("Categorize(%d, %q) = %q, want %q", tt.code, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestDivergenceReason(t *testing.T) {
+	result := Shape{Kind: "result"}
+	invalid := Shape{Kind: "invalid"}
+	nonceLow := Shape{Kind: "error", Code: -32000, Category: "NONCE_TOO_LOW", Message: "nonce too low"}
+	nonceLowBesu := Shape{Kind: "error", Code: -32001, Category: "NONCE_TOO_LOW", Message: "Nonce too low"}
+	funds := Shape{Kind: "error", Code: -32000, Category: "INSUFFICIENT_FUNDS"}
+
+	tests := []struct {
+		name   string
+		shapes []Shape
+		want   string
+	}{
+		{"same result", []Shape{result, result}, ""},
+		{"same category, different wording", []Shape{nonceLow, {Kind: "error", Code: -32000, Category: "NONCE_TOO_LOW", Message: "other"}}, ""},
+		{"success vs error", []Shape{result, nonceLow}, ReasonSuccessVsError},
+		{"error vs invalid", []Shape{nonceLow, invalid}, ReasonResponseFormat},
+		{"code", []Shape{nonceLow, nonceLowBesu}, ReasonErrorCode},
+		{"category", []Shape{nonceLow, funds}, ReasonErrorCategory},
+		{"most significant wins", []Shape{nonceLow, funds, nonceLowBesu, result}, ReasonSuccessVsError},
+	}
+
+	for _, tt := range tests {
+		var outcomes []Outcome
+		for _, shape := range tt.shapes {
+			outcomes = append(outcomes, Outcome{Shape: shape})
+		}
+		if got := divergenceReason(outcomes); got != tt.want {
+			t.Errorf("%s: divergenceReason = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func startMockServer(t *testing.T, profile mockrpc.Profile) string {
+	t.Helper()
+	server := mockrpc.NewServer(profile)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return url
+}
+
+func runDiffer(t *testing.T, endpoints []Endpoint, mutate bool, testCase pkgTypes.TestCase) *DiffReport {
+	t.Helper()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	return NewDiffer(endpoints, mutate).Run([]pkgTypes.TestCase{testCase})
+}
+
+func TestDifferRun(t *testing.T) {
+	geth := startMockServer(t, mockrpc.Profile{
+		Methods: map[string][]mockrpc.Rule{
+			"eth_getBalance": {
+				{Match: `^\["0xaa","latest"\]$`, Result: json.RawMessage(`"0x0"`)},
+				{Error: &mockrpc.Error{Code: -32602, Message: "invalid argument 0: hex string has length 4, want 40"}},
+			},
+		},
+	})
+	besu := startMockServer(t, mockrpc.Profile{
+		Methods: map[string][]mockrpc.Rule{
+			"eth_getBalance": {
+				{Match: `^\["0xaa","latest"\]$`, Result: json.RawMessage(`"0x10"`)},
+				{Error: &mockrpc.Error{Code: -32000, Message: "Invalid params"}},
+			},
+		},
+	})
+	endpoints := []Endpoint{{Name: "geth", Cfg: config.Config{Url: geth}}, {Name: "besu", Cfg: config.Config{Url: besu}}}
+
+	report := runDiffer(t, endpoints, false, &seedTestCase{})
+	if report.Requests != 2 {
+		t.Errorf("requests = %d, want 2", report.Requests)
+	}
+	// Different result values are not divergences, different codes for the same category are
+	if len(report.Divergences) != 1 {
+		t.Fatalf("divergences = %d, want 1", len(report.Divergences))
+	}
+	divergence := report.Divergences[0]
+	if divergence.Reason != ReasonErrorCode || divergence.Scenario != "Invalid address" || len(divergence.Outcomes) != 2 {
+		t.Errorf("unexpected divergence: %+v", divergence)
+	}
+	if divergence.Outcomes[0].Endpoint != "geth" || divergence.Outcomes[1].Shape.Category != "INVALID_PARAMS" {
+		t.Errorf("unexpected outcomes: %+v", divergence.Outcomes)
+	}
+
+	report = runDiffer(t, []Endpoint{{Name: "geth", Cfg: config.Config{Url: geth}}, {Name: "geth-copy", Cfg: config.Config{Url: geth}}}, true, &seedTestCase{})
+	if report.Requests != len(Mutate([]interface{}{"0xaa", "latest"})) {
+		t.Errorf("requests = %d, want one per mutation", report.Requests)
+	}
+	if len(report.Divergences) != 0 {
+		t.Errorf("identical endpoints diverged: %+v", report.Divergences[0])
+	}
+}
+
+// accountTestCase renders requests from the config's account, and a block hash lookup only when the config
+// knows a deployment tx.
+type accountTestCase struct{}
+
+func (a *accountTestCase) Name() string              { return "account" }
+func (a *accountTestCase) RequiresContract() bool    { return false }
+func (a *accountTestCase) Execute(cfg config.Config) {}
+func (a *accountTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{JsonRpc: "2.0", Id: 1, Method: "eth_getBalance", Params: []interface{}{cfg.From, "latest"}},
+			Desc:           "Own account",
+		},
+	}
+	if tx, ok := cfg.DeploymentTxs["storage"]; ok {
+		requests = append(requests, pkgTypes.Meta{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{JsonRpc: "2.0", Id: 2, Method: "eth_getTransactionByHash", Params: []interface{}{tx.Hex()}},
+			Desc:           "Deployment tx",
+		})
+	}
+	return requests
+}
+
+func TestDifferRendersPerEndpoint(t *testing.T) {
+	profile := func(account string) mockrpc.Profile {
+		return mockrpc.Profile{
+			Methods: map[string][]mockrpc.Rule{
+				"eth_getBalance": {
+					{Match: `^\["` + account + `","latest"\]$`, Result: json.RawMessage(`"0x1"`)},
+					{Error: &mockrpc.Error{Code: -32602, Message: "unknown account"}},
+				},
+			},
+		}
+	}
+	first := startMockServer(t, profile("0xaa"))
+	second := startMockServer(t, profile("0xbb"))
+	endpoints := []Endpoint{
+		{Name: "first", Cfg: config.Config{Url: first, From: "0xaa", DeploymentTxs: map[string]common.Hash{"storage": {1}}}},
+		{Name: "second", Cfg: config.Config{Url: second, From: "0xbb"}},
+	}
+
+	// Each endpoint is asked about its own account, and the scenario only the first one renders is skipped
+	report := runDiffer(t, endpoints, false, &accountTestCase{})
+	if report.Requests != 1 || report.Skipped != 1 {
+		t.Errorf("requests = %d, skipped = %d, want 1 and 1", report.Requests, report.Skipped)
+	}
+	if len(report.Divergences) != 0 {
+		t.Errorf("unexpected divergence: %+v", report.Divergences[0])
+	}
+
+	endpoints[1].Cfg.From = "0xcc"
+	report = runDiffer(t, endpoints, false, &accountTestCase{})
+	if len(report.Divergences) != 1 || report.Divergences[0].Outcomes[1].Request == "" {
+		t.Fatalf("expected a divergence carrying the second endpoint's request, got %+v", report.Divergences)
+	}
+	if report.Divergences[0].Outcomes[0].Request != "" {
+		t.Error("the first endpoint's request is the divergence's request")
+	}
+}
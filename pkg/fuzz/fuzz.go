@@ -0,0 +1,177 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// Finding is a distinct response shape of a method, with the first mutation that produced it.
+type Finding struct {
+	Method   string `json:"method"`
+	Shape    Shape  `json:"shape"`
+	Count    int    `json:"count"`
+	TestCase string `json:"testCase"`
+	Mutation string `json:"mutation"`
+	Request  string `json:"request"`
+	Response string `json:"response"`
+}
+
+// Report holds the findings of one client, in the order they were first seen.
+type Report struct {
+	Network  string     `json:"network"`
+	Url      string     `json:"url"`
+	Requests int        `json:"requests"`
+	Findings []*Finding `json:"findings"`
+
+	byKey map[string]*Finding
+}
+
+type Fuzzer struct {
+	cfg config.Config
+}
+
+func New(cfg config.Config) *Fuzzer {
+	return &Fuzzer{cfg: cfg}
+}
+
+// Seeds returns the "Proper request" entries of a test case, which are the valid requests to mutate.
+// Requests with extra headers target the Engine API endpoint and are skipped.
+func Seeds(testCase pkgTypes.TestCase, cfg config.Config) []pkgTypes.JsonRpcRequest {
+	var seeds []pkgTypes.JsonRpcRequest
+	for _, request := range testCase.GetRequests(cfg) {
+		if len(request.Headers) > 0 || !strings.Contains(strings.ToLower(request.Desc), "proper request") {
+			continue
+		}
+		seeds = append(seeds, request.JsonRpcRequest)
+	}
+	return seeds
+}
+
+// Run sends every mutation of every seed of the test cases and records the distinct response shapes.
+// The first request of each new shape is printed in the usual Scenario/Response format.
+func (f *Fuzzer) Run(testCases []pkgTypes.TestCase) *Report {
+	report := &Report{
+		Network: f.cfg.Network,
+		Url:     f.cfg.Url,
+		byKey:   make(map[string]*Finding),
+	}
+
+	for _, testCase := range testCases {
+		for _, seed := range Seeds(testCase, f.cfg) {
+			params, err := normalizeParams(seed.Params)
+			if err != nil {
+				fmt.Printf("Skipping %s seed %s: %v\n", testCase.Name(), seed.Method, err)
+				continue
+			}
+
+			for _, mutation := range Mutate(params) {
+				request := seed
+				request.Params = mutation.Params
+				f.send(report, testCase.Name(), mutation.Desc, request)
+			}
+		}
+	}
+
+	return report
+}
+
+func (f *Fuzzer) send(report *Report, testCase, mutation string, request pkgTypes.JsonRpcRequest) {
+	r, err := json.Marshal(request)
+	if err != nil {
+		fmt.Printf("Skipping %s mutation %q: %v\n", testCase, mutation, err)
+		return
+	}
+	report.Requests++
+
+	var shape Shape
+	response, err := jsonrpc.SendRawJSONRPCRequest(f.cfg.Url, []pkgTypes.JsonRpcRequest{request})
+	if err != nil {
+		shape = Shape{Kind: "invalid", Message: "transport error"}
+		response = err.Error()
+	} else {
+		shape = Classify(response)
+	}
+
+	key := request.Method + " " + shape.Key()
+	if finding, ok := report.byKey[key]; ok {
+		finding.Count++
+		return
+	}
+
+	finding := &Finding{
+		Method:   request.Method,
+		Shape:    shape,
+		Count:    1,
+		TestCase: testCase,
+		Mutation: mutation,
+		Request:  string(r),
+		Response: compact(response),
+	}
+	report.byKey[key] = finding
+	report.Findings = append(report.Findings, finding)
+
+	fmt.Println("Scenario:", "Fuzz "+mutation, " - Request:", truncate(finding.Request, 1000))
+	fmt.Println("Response:", finding.Response)
+}
+
+// PrintSummary prints the number of distinct shapes and requests per method.
+func (r *Report) PrintSummary() {
+	fmt.Println("=======================================================")
+	fmt.Printf("Fuzz summary for %s: %d requests, %d distinct shapes\n", r.Network, r.Requests, len(r.Findings))
+
+	type methodStats struct{ shapes, requests int }
+	var methods []string
+	stats := make(map[string]*methodStats)
+	for _, finding := range r.Findings {
+		if _, ok := stats[finding.Method]; !ok {
+			methods = append(methods, finding.Method)
+			stats[finding.Method] = &methodStats{}
+		}
+		stats[finding.Method].shapes++
+		stats[finding.Method].requests += finding.Count
+	}
+	for _, method := range methods {
+		fmt.Printf("  %-40s %3d shapes / %4d requests\n", method, stats[method].shapes, stats[method].requests)
+	}
+}
+
+// WriteJSON writes the report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// normalizeParams round-trips params through JSON so typed values (structs, map[string]string, big.Int)
+// become the generic values Mutate works on.
+func normalizeParams(params []interface{}) ([]interface{}, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	var normalized []interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	if normalized == nil {
+		normalized = []interface{}{}
+	}
+	return normalized, nil
+}
+
+func compact(response string) string {
+	var data interface{}
+	if err := json.Unmarshal([]byte(response), &data); err != nil {
+		return strings.TrimSpace(response)
+	}
+	compactJSON, _ := json.Marshal(data)
+	return string(compactJSON)
+}
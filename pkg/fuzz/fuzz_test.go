@@ -0,0 +1,173 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+func mutationsByDesc(mutations []Mutation) map[string][]interface{} {
+	byDesc := make(map[string][]interface{}, len(mutations))
+	for _, mutation := range mutations {
+		byDesc[mutation.Desc] = mutation.Params
+	}
+	return byDesc
+}
+
+func TestMutate(t *testing.T) {
+	seed := []interface{}{
+		map[string]interface{}{"to": "0xaa", "data": "0x"},
+		"latest",
+	}
+	mutations := mutationsByDesc(Mutate(seed))
+
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{"no params", `[]`},
+		{"missing last param", `[{"data":"0x","to":"0xaa"}]`},
+		{"extra param", `[{"data":"0x","to":"0xaa"},"latest","0x1"]`},
+		{"param 1: null", `[{"data":"0x","to":"0xaa"},null]`},
+		{"param 1: uppercase", `[{"data":"0x","to":"0xaa"},"LATEST"]`},
+		{"param 0: field to: odd length", `[{"data":"0x","to":"0xaa0"},"latest"]`},
+		{"param 0: field to: missing 0x prefix", `[{"data":"0x","to":"aa"},"latest"]`},
+		{"param 0: field to: uppercase 0X prefix", `[{"data":"0x","to":"0Xaa"},"latest"]`},
+		{"param 0: field to: leading zeros", `[{"data":"0x","to":"0x00aa"},"latest"]`},
+		{"param 0: field to: overflow 2^256", `[{"data":"0x","to":"0x1` + strings.Repeat("0", 64) + `"},"latest"]`},
+		{"param 0: field data missing", `[{"to":"0xaa"},"latest"]`},
+		{"param 0: unknown field", `[{"data":"0x","to":"0xaa","unknownField":"0x1"},"latest"]`},
+		{"param 0: field to: unicode", `[{"data":"0x","to":"é‮💥"},"latest"]`},
+	}
+
+	for _, tt := range tests {
+		params, ok := mutations[tt.desc]
+		if !ok {
+			t.Errorf("missing mutation %q", tt.desc)
+			continue
+		}
+		got, err := json.Marshal(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.desc, got, tt.want)
+		}
+	}
+
+	if original, _ := json.Marshal(seed); string(original) != `[{"data":"0x","to":"0xaa"},"latest"]` {
+		t.Errorf("seed was modified: %s", original)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{`[{"jsonrpc":"2.0","id":1,"result":"0x1"}]`, `result`},
+		{`[{"jsonrpc":"2.0","id":1,"result":null}]`, `result`},
+		{
+			`[{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid argument 0: hex string 0x1234 has length 4, want 40"}}]`,
+			`error -32602 "invalid argument #: hex string 0x… has length #, want #" data=false`,
+		},
+		{`[{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted","data":"0x08c379a0"}}]`, `error 3 "execution reverted" data=true`},
+		{`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch too large"}}`, `invalid "{\"jsonrpc\":\"#.#\",\"id\":null,\"error\":{\"code\":-#,\"message\":\"batch too large\"}}"`},
+		{`Unauthorized`, `invalid "Unauthorized"`},
+	}
+
+	for _, tt := range tests {
+		if got := Classify(tt.response).Key(); got != tt.want {
+			t.Errorf("Classify(%s) = %s, want %s", tt.response, got, tt.want)
+		}
+	}
+}
+
+type seedTestCase struct{}
+
+func (s *seedTestCase) Name() string              { return "seed" }
+func (s *seedTestCase) RequiresContract() bool    { return false }
+func (s *seedTestCase) Execute(cfg config.Config) {}
+func (s *seedTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	return []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{JsonRpc: "2.0", Id: 1, Method: "eth_getBalance", Params: []interface{}{"0xaa", "latest"}},
+			Desc:           "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{JsonRpc: "2.0", Id: 2, Method: "eth_getBalance", Params: []interface{}{"0x1234"}},
+			Desc:           "Invalid address",
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	profile := mockrpc.Profile{
+		Methods: map[string][]mockrpc.Rule{
+			"eth_getBalance": {
+				{Match: `^\["0xaa","latest"\]$`, Result: json.RawMessage(`"0x0"`)},
+				{Match: `^\[\]$|^\["0xaa"\]$`, Error: &mockrpc.Error{Code: -32602, Message: "missing value for required argument 1"}},
+				{Error: &mockrpc.Error{Code: -32602, Message: "invalid argument 0"}},
+			},
+		},
+	}
+	server := mockrpc.NewServer(profile)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	report := New(config.Config{Network: "mock", Url: url}).Run([]pkgTypes.TestCase{&seedTestCase{}})
+	os.Stdout = stdout
+
+	if report.Requests != len(Mutate([]interface{}{"0xaa", "latest"})) {
+		t.Errorf("requests = %d, want one per mutation", report.Requests)
+	}
+	if report.Requests != len(server.Requests()) {
+		t.Errorf("report counted %d requests, server received %d", report.Requests, len(server.Requests()))
+	}
+
+	var keys []string
+	total := 0
+	for _, finding := range report.Findings {
+		keys = append(keys, finding.Shape.Key())
+		total += finding.Count
+	}
+	want := []string{
+		`error -32602 "missing value for required argument #" data=false`,
+		`error -32602 "invalid argument #" data=false`,
+	}
+	if strings.Join(keys, "|") != strings.Join(want, "|") {
+		t.Errorf("shapes = %v, want %v", keys, want)
+	}
+	if total != report.Requests {
+		t.Errorf("finding counts sum to %d, want %d", total, report.Requests)
+	}
+
+	path := filepath.Join(t.TempDir(), "fuzz.json")
+	if err := report.WriteJSON(path); err != nil {
+		t.Fatal(err)
+	}
+	var written Report
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &written); err != nil || len(written.Findings) != 2 {
+		t.Errorf("unexpected written report: %s", data)
+	}
+}
@@ -0,0 +1,145 @@
+// Package fuzz mutates the params of valid requests and groups the responses into distinct shapes,
+// giving broader error coverage than the hand-written scenarios.
+package fuzz
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Mutation is a variant of a valid request's params.
+type Mutation struct {
+	Desc   string
+	Params []interface{}
+}
+
+var hexRegexp = regexp.MustCompile(`^0x[0-9a-fA-F]*$`)
+
+// overflowQuantity is 2^256, one above the largest value a QUANTITY can hold
+var overflowQuantity = "0x1" + strings.Repeat("0", 64)
+
+// Mutate returns the param list mutations followed by the mutations of every param, in a stable order.
+func Mutate(params []interface{}) []Mutation {
+	mutations := []Mutation{
+		{Desc: "no params", Params: []interface{}{}},
+		{Desc: "extra param", Params: append(copyParams(params), "0x1")},
+		{Desc: "extra null param", Params: append(copyParams(params), nil)},
+	}
+	if len(params) > 0 {
+		mutations = append(mutations, Mutation{Desc: "missing last param", Params: copyParams(params[:len(params)-1])})
+	}
+
+	for i, param := range params {
+		for _, variant := range mutateValue(param) {
+			mutated := copyParams(params)
+			mutated[i] = variant.value
+			mutations = append(mutations, Mutation{
+				Desc:   fmt.Sprintf("param %d: %s", i, variant.desc),
+				Params: mutated,
+			})
+		}
+	}
+
+	return mutations
+}
+
+type variant struct {
+	desc  string
+	value interface{}
+}
+
+// mutateValue returns type swaps for any value plus value-specific edge cases. Object fields are
+// mutated one level deep.
+func mutateValue(value interface{}) []variant {
+	variants := []variant{
+		{"null", nil},
+		{"number", 1},
+		{"boolean", true},
+		{"empty object", map[string]interface{}{}},
+		{"array", []interface{}{value}},
+	}
+
+	switch v := value.(type) {
+	case string:
+		variants = append(variants, variant{"unicode", "é‮\U0001F4A5"}, variant{"empty string", ""})
+		if hexRegexp.MatchString(v) {
+			variants = append(variants, hexVariants(v)...)
+		} else {
+			variants = append(variants,
+				variant{"uppercase", strings.ToUpper(v)},
+				variant{"trailing unicode", v + "é"},
+			)
+		}
+	case map[string]interface{}:
+		variants = append(variants, variant{"string", "{}"})
+		for _, key := range sortedKeys(v) {
+			withoutKey := copyObject(v)
+			delete(withoutKey, key)
+			variants = append(variants, variant{fmt.Sprintf("field %s missing", key), withoutKey})
+
+			for _, fieldVariant := range mutateValue(v[key]) {
+				if _, nested := fieldVariant.value.(map[string]interface{}); nested {
+					continue
+				}
+				mutated := copyObject(v)
+				mutated[key] = fieldVariant.value
+				variants = append(variants, variant{fmt.Sprintf("field %s: %s", key, fieldVariant.desc), mutated})
+			}
+		}
+		withUnknown := copyObject(v)
+		withUnknown["unknownField"] = "0x1"
+		variants = append(variants, variant{"unknown field", withUnknown})
+	case []interface{}:
+		variants = append(variants, variant{"empty array", []interface{}{}})
+	default:
+		variants = append(variants, variant{"string", fmt.Sprint(v)})
+	}
+
+	return variants
+}
+
+func hexVariants(v string) []variant {
+	digits := v[2:]
+	oddDigits := digits + "0"
+	if len(digits)%2 == 1 {
+		oddDigits = digits + "00"
+	}
+	variants := []variant{
+		{"missing 0x prefix", digits},
+		{"uppercase 0X prefix", "0X" + digits},
+		{"empty hex", "0x"},
+		{"non-hex digit", "0x" + digits + "g"},
+		{"leading zeros", "0x00" + digits},
+		{"overflow 2^256", overflowQuantity},
+		{"negative", "-" + v},
+		{"odd length", "0x" + oddDigits},
+		{"uppercase digits", "0x" + strings.ToUpper(digits)},
+	}
+	if len(digits) > 0 {
+		variants = append(variants, variant{"truncated", v[:len(v)-1]})
+	}
+	return variants
+}
+
+func copyParams(params []interface{}) []interface{} {
+	return append([]interface{}{}, params...)
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		copied[key] = value
+	}
+	return copied
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
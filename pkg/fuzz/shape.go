@@ -0,0 +1,75 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// valueRegexp matches the hex values and numbers that vary between otherwise identical messages
+var valueRegexp = regexp.MustCompile(`0[xX][0-9a-fA-F]+|\d+`)
+
+// Shape is the normalized form of a response. Responses with the same Key are the same finding.
+type Shape struct {
+	Kind     string `json:"kind"` // "result", "error" or "invalid" (not a JSON-RPC response)
+	Code     int    `json:"code,omitempty"`
+	Category string `json:"category,omitempty"` // See Categorize
+	Message  string `json:"message,omitempty"`  // Normalized: hex values become 0x…, numbers become #
+	HasData  bool   `json:"hasData,omitempty"`
+}
+
+func (s Shape) Key() string {
+	switch s.Kind {
+	case "error":
+		return fmt.Sprintf("error %d %q data=%t", s.Code, s.Message, s.HasData)
+	case "invalid":
+		return fmt.Sprintf("invalid %q", s.Message)
+	default:
+		return s.Kind
+	}
+}
+
+// Classify normalizes a single-request batch response. Results only differ in their values, so
+// every successful response has the same shape.
+func Classify(response string) Shape {
+	var batchResult []struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int             `json:"code"`
+			Message string          `json:"message"`
+			Data    json.RawMessage `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(response), &batchResult); err != nil || len(batchResult) != 1 {
+		return Shape{Kind: "invalid", Message: normalizeMessage(truncate(strings.TrimSpace(response), 200))}
+	}
+
+	res := batchResult[0]
+	if res.Error == nil {
+		return Shape{Kind: "result"}
+	}
+	return Shape{
+		Kind:     "error",
+		Code:     res.Error.Code,
+		Category: Categorize(res.Error.Code, res.Error.Message),
+		Message:  normalizeMessage(res.Error.Message),
+		HasData:  len(res.Error.Data) > 0 && string(res.Error.Data) != "null",
+	}
+}
+
+func normalizeMessage(message string) string {
+	return valueRegexp.ReplaceAllStringFunc(message, func(value string) string {
+		if len(value) > 2 && (value[1] == 'x' || value[1] == 'X') {
+			return "0x…"
+		}
+		return "#"
+	})
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max] + "..."
+	}
+	return s
+}
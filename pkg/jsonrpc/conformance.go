@@ -0,0 +1,168 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/eth-error-tests/pkg/types"
+)
+
+// Error codes after which the server could not read the request id, so a null id is allowed
+var unreadableRequestCodes = map[int]bool{-32700: true, -32600: true}
+
+// CheckConformance checks a response to a batch of requests against the JSON-RPC 2.0 specification: every
+// entry has "jsonrpc": "2.0", echoes a request id, and carries either a result or an error object with an
+// integer code and a string message. These are protocol violations, reported apart from the expected code and
+// message of the scenario. Bodies that are not JSON (e.g. HTTP 401 from the Engine API) are not checked and
+// yield an empty string.
+func CheckConformance(requests []types.JsonRpcRequest, response string) string {
+	trimmed := bytes.TrimSpace([]byte(response))
+	if !json.Valid(trimmed) {
+		return ""
+	}
+
+	var violations []string
+	var entries []json.RawMessage
+	isBatch := len(trimmed) > 0 && trimmed[0] == '['
+	if isBatch {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return ""
+		}
+		if len(entries) == 0 {
+			violations = append(violations, "empty batch response")
+		}
+	} else {
+		violations = append(violations, "batch answered with a single response object")
+		entries = []json.RawMessage{trimmed}
+	}
+
+	pending := make(map[int]int) // Unanswered requests per id, ids may repeat within a batch
+	for _, request := range requests {
+		pending[request.Id]++
+	}
+
+	for i, entry := range entries {
+		path := "response"
+		if isBatch {
+			path = fmt.Sprintf("[%d]", i)
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(entry, &fields); err != nil || fields == nil {
+			violations = append(violations, path+" is not an object")
+			continue
+		}
+
+		if version, ok := fields["jsonrpc"]; !ok {
+			violations = append(violations, path+`: missing "jsonrpc"`)
+		} else if string(version) != `"2.0"` {
+			violations = append(violations, fmt.Sprintf(`%s.jsonrpc is %s, want "2.0"`, path, version))
+		}
+
+		_, hasResult := fields["result"]
+		rpcErr, hasError := fields["error"]
+		switch {
+		case hasResult && hasError:
+			violations = append(violations, path+`: both "result" and "error"`)
+		case !hasResult && !hasError:
+			violations = append(violations, path+`: neither "result" nor "error"`)
+		}
+
+		code := 0
+		if hasError {
+			var errViolations []string
+			code, errViolations = checkErrorObject(path+".error", rpcErr)
+			violations = append(violations, errViolations...)
+		}
+
+		id, hasId := fields["id"]
+		switch {
+		case !hasId:
+			violations = append(violations, path+`: missing "id"`)
+		case string(id) == "null":
+			if !hasError || !unreadableRequestCodes[code] {
+				violations = append(violations, path+".id is null")
+			}
+		default:
+			var value int
+			if err := json.Unmarshal(id, &value); err != nil {
+				violations = append(violations, fmt.Sprintf("%s.id is %s, want a request id", path, id))
+			} else if pending[value] == 0 {
+				violations = append(violations, fmt.Sprintf("%s.id %d does not echo a request id", path, value))
+			} else {
+				pending[value]--
+			}
+		}
+	}
+
+	if len(entries) > 0 && len(entries) != len(requests) {
+		violations = append(violations, fmt.Sprintf("%d responses for %d requests", len(entries), len(requests)))
+	}
+
+	if len(violations) > 0 {
+		return fmt.Sprintf("Conformance: FAIL (%s)", strings.Join(violations, "; "))
+	}
+	return "Conformance: PASS"
+}
+
+// CheckErrorData reports whether the error objects of a response carry the optional "data" member. It returns
+// an empty string when the response has no errors or is not JSON.
+func CheckErrorData(response string) string {
+	trimmed := bytes.TrimSpace([]byte(response))
+	var entries []json.RawMessage
+	if err := json.Unmarshal(trimmed, &entries); err != nil {
+		entries = []json.RawMessage{trimmed}
+	}
+
+	errorCount, withData := 0, 0
+	for _, entry := range entries {
+		var fields struct {
+			Error map[string]json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal(entry, &fields); err != nil || fields.Error == nil {
+			continue
+		}
+		errorCount++
+		if _, ok := fields.Error["data"]; ok {
+			withData++
+		}
+	}
+
+	switch {
+	case errorCount == 0:
+		return ""
+	case withData == 0:
+		return "Error data: absent"
+	case withData == errorCount:
+		return "Error data: present"
+	default:
+		return fmt.Sprintf("Error data: present in %d/%d", withData, errorCount)
+	}
+}
+
+// checkErrorObject returns the error code, 0 if unreadable, and the violations of a JSON-RPC error object.
+func checkErrorObject(path string, raw json.RawMessage) (int, []string) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return 0, []string{path + " is not an object"}
+	}
+
+	var violations []string
+	code := 0
+	if rawCode, ok := fields["code"]; !ok {
+		violations = append(violations, path+`: missing "code"`)
+	} else if err := json.Unmarshal(rawCode, &code); err != nil {
+		violations = append(violations, fmt.Sprintf("%s.code is %s, want an integer", path, rawCode))
+	}
+
+	var message string
+	if rawMessage, ok := fields["message"]; !ok {
+		violations = append(violations, path+`: missing "message"`)
+	} else if err := json.Unmarshal(rawMessage, &message); err != nil || string(rawMessage) == "null" {
+		violations = append(violations, fmt.Sprintf("%s.message is %s, want a string", path, rawMessage))
+	}
+
+	return code, violations
+}
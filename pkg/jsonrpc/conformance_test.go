@@ -0,0 +1,83 @@
+package jsonrpc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/eth-error-tests/pkg/types"
+)
+
+func TestCheckConformance(t *testing.T) {
+	single := []types.JsonRpcRequest{{JsonRpc: "2.0", Id: 7, Method: "eth_call"}}
+	pair := []types.JsonRpcRequest{{JsonRpc: "2.0", Id: 2, Method: "eth_sendRawTransaction"}, {JsonRpc: "2.0", Id: 2, Method: "eth_sendRawTransaction"}}
+
+	tests := []struct {
+		requests []types.JsonRpcRequest
+		response string
+		want     string
+	}{
+		{single, `[{"jsonrpc":"2.0","id":7,"result":"0x"}]`, "Conformance: PASS"},
+		{single, `[{"jsonrpc":"2.0","id":7,"error":{"code":3,"message":"execution reverted","data":"0x08c379a0"}}]`, "Conformance: PASS"},
+		{single, `[{"jsonrpc":"2.0","id":7,"error":{"code":-32000,"message":"nonce too low"}}]`, "Conformance: PASS"},
+		{pair, `[{"jsonrpc":"2.0","id":2,"result":"0xaa"},{"jsonrpc":"2.0","id":2,"error":{"code":-32000,"message":"already known","data":{}}}]`,
+			"Conformance: PASS"},
+		{pair, `[{"jsonrpc":"2.0","id":2,"error":{"code":-1,"message":"a","data":1}},{"jsonrpc":"2.0","id":2,"error":{"code":-1,"message":"b"}}]`,
+			"Conformance: PASS"},
+		{single, `[{"jsonrpc":"1.0","id":7,"result":"0x"}]`, `Conformance: FAIL ([0].jsonrpc is "1.0", want "2.0")`},
+		{single, `[{"id":7,"result":"0x"}]`, `Conformance: FAIL ([0]: missing "jsonrpc")`},
+		{single, `[{"jsonrpc":"2.0","id":"7","result":"0x"}]`, `Conformance: FAIL ([0].id is "7", want a request id)`},
+		{single, `[{"jsonrpc":"2.0","id":8,"result":"0x"}]`, `Conformance: FAIL ([0].id 8 does not echo a request id)`},
+		{single, `[{"jsonrpc":"2.0","result":"0x"}]`, `Conformance: FAIL ([0]: missing "id")`},
+		{single, `[{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"invalid request"}}]`, "Conformance: PASS"},
+		{single, `[{"jsonrpc":"2.0","id":null,"error":{"code":-32000,"message":"x"}}]`, "Conformance: FAIL ([0].id is null)"},
+		{single, `[{"jsonrpc":"2.0","id":7,"result":"0x","error":{"code":1,"message":"x"}}]`, `Conformance: FAIL ([0]: both "result" and "error")`},
+		{single, `[{"jsonrpc":"2.0","id":7}]`, `Conformance: FAIL ([0]: neither "result" nor "error")`},
+		{single, `[{"jsonrpc":"2.0","id":7,"error":{"code":"-32000","message":"x"}}]`, `Conformance: FAIL ([0].error.code is "-32000", want an integer)`},
+		{single, `[{"jsonrpc":"2.0","id":7,"error":{"code":-32000.5,"message":"x"}}]`, `Conformance: FAIL ([0].error.code is -32000.5, want an integer)`},
+		{single, `[{"jsonrpc":"2.0","id":7,"error":{"code":-32000}}]`, `Conformance: FAIL ([0].error: missing "message")`},
+		{single, `[{"jsonrpc":"2.0","id":7,"error":"nonce too low"}]`, `Conformance: FAIL ([0].error is not an object)`},
+		{single, `{"jsonrpc":"2.0","id":7,"result":"0x"}`, "Conformance: FAIL (batch answered with a single response object)"},
+		{pair, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch too large"}}`,
+			"Conformance: FAIL (batch answered with a single response object; 1 responses for 2 requests)"},
+		{single, `[]`, "Conformance: FAIL (empty batch response)"},
+		{pair, `[{"jsonrpc":"2.0","id":2,"result":"0xaa"}]`, "Conformance: FAIL (1 responses for 2 requests)"},
+		{single, `[1]`, "Conformance: FAIL ([0] is not an object)"},
+		{single, `Unauthorized`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := CheckConformance(tt.requests, tt.response); !strings.HasPrefix(got, tt.want) || (tt.want == "" && got != "") {
+			t.Errorf("CheckConformance(%s)\n got %q\nwant %q", tt.response, got, tt.want)
+		}
+	}
+}
+
+func TestCheckErrorData(t *testing.T) {
+	tests := map[string]string{
+		`[{"jsonrpc":"2.0","id":7,"result":"0x"}]`:                                                                                         "",
+		`[{"jsonrpc":"2.0","id":7,"error":{"code":3,"message":"execution reverted","data":"0x08c379a0"}}]`:                                 "Error data: present",
+		`[{"jsonrpc":"2.0","id":7,"error":{"code":-32000,"message":"nonce too low"}}]`:                                                     "Error data: absent",
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch too large"}}`:                                                  "Error data: absent",
+		`[{"jsonrpc":"2.0","id":2,"result":"0xaa"},{"jsonrpc":"2.0","id":2,"error":{"code":-1,"message":"a","data":{}}}]`:                  "Error data: present",
+		`[{"jsonrpc":"2.0","id":2,"error":{"code":-1,"message":"a","data":1}},{"jsonrpc":"2.0","id":2,"error":{"code":-1,"message":"b"}}]`: "Error data: present in 1/2",
+		`Unauthorized`: "",
+	}
+	for response, want := range tests {
+		if got := CheckErrorData(response); got != want {
+			t.Errorf("CheckErrorData(%s) = %q, want %q", response, got, want)
+		}
+	}
+}
+
+func TestSendReqFlagsNonConformingResponses(t *testing.T) {
+	_, cfg := startDevChain(t, mockrpc.Profile{Batch: mockrpc.BatchUnwrapSingle})
+
+	requests := []types.Meta{
+		{JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 1, Method: "eth_chainId", Params: []interface{}{}}, Desc: "Proper request"},
+	}
+	out := captureStdout(t, func() { SendReq(requests, cfg) })
+	if !strings.Contains(out, "Conformance: FAIL (batch answered with a single response object)") {
+		t.Errorf("expected a conformance failure:\n%s", out)
+	}
+}
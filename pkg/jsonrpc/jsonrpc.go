@@ -8,6 +8,8 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -18,20 +20,38 @@ import (
 
 	"github.com/eth-error-tests/pkg/config"
 	"github.com/eth-error-tests/pkg/contract"
+	"github.com/eth-error-tests/pkg/openrpc"
 	"github.com/eth-error-tests/pkg/types"
 )
 
 const (
+	// SEQUENCE_BATCH_SIZE keeps sequence batches below the clients' batch request limits (geth: 1000)
+	SEQUENCE_BATCH_SIZE = 500
+
 	CONTRACT_ABI = "[{\"inputs\":[],\"name\":\"retrieve\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"num\",\"type\":\"uint256\"}],\"name\":\"store\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 )
 
 func SendRawJSONRPCRequest(url string, requestBody []types.JsonRpcRequest) (string, error) {
+	return SendRawJSONRPCRequestWithHeaders(url, nil, requestBody)
+}
+
+// SendRawJSONRPCRequestWithHeaders is SendRawJSONRPCRequest with extra HTTP headers set on the request.
+func SendRawJSONRPCRequestWithHeaders(url string, headers map[string]string, requestBody []types.JsonRpcRequest) (string, error) {
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -49,40 +69,164 @@ func SendRawJSONRPCRequest(url string, requestBody []types.JsonRpcRequest) (stri
 	return string(body), nil
 }
 
+// SendReq sends every request on its own and prints it with its response. With cfg.Parallel > 1 and only
+// read-only methods, the requests are sent concurrently while the output keeps their order.
 func SendReq(requests []types.Meta, cfg config.Config) {
+	lanes := cfg.Parallel
 	for _, request := range requests {
-		r, err := json.Marshal(request.JsonRpcRequest)
-		if err != nil {
-			fmt.Println(err)
-			return
+		if !IsReadOnly(request.Method) {
+			lanes = 1
+			break
 		}
-		reqStr := string(r)
-		if len(reqStr) > 1000 {
-			reqStr = reqStr[:1000] + "..."
-		}
-		fmt.Println("Scenario:", request.Desc, " - Request:", reqStr)
-		// fmt.Println("Request:", string(r))
-		response, err := SendRawJSONRPCRequest(cfg.Url, []types.JsonRpcRequest{request.JsonRpcRequest})
-		if err != nil {
-			fmt.Println("Error:", err)
-			return
+	}
+
+	runOrdered(len(requests), lanes, nil, func(i, _ int, out io.Writer) bool {
+		return sendRequest(requests[i], cfg, out)
+	})
+}
+
+// sendRequest prints a request and its response to out. It returns false if the request could not be sent.
+func sendRequest(request types.Meta, cfg config.Config, out io.Writer) bool {
+	r, err := json.Marshal(request.JsonRpcRequest)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return false
+	}
+	reqStr := string(r)
+	if len(reqStr) > 1000 {
+		reqStr = reqStr[:1000] + "..."
+	}
+	fmt.Fprintln(out, "Scenario:", request.Desc, " - Request:", reqStr)
+	response, err := SendRawJSONRPCRequestWithHeaders(cfg.Url, request.Headers, []types.JsonRpcRequest{request.JsonRpcRequest})
+	if err != nil {
+		fmt.Fprintln(out, "Error:", err)
+		return false
+	}
+	// Non JSON bodies (e.g. HTTP 401 from the Engine API) are printed as-is
+	var data interface{}
+	printResp := strings.TrimSpace(response)
+	if err := json.Unmarshal([]byte(response), &data); err == nil {
+		compactJSON, _ := json.Marshal(data)
+		printResp = string(compactJSON)
+	}
+	if request.ExpectedCode != 0 {
+		printResp += ", " + CheckExpectedCode(response, request.ExpectedCode)
+	}
+	if request.ExpectedMessage != "" {
+		printResp += ", " + CheckExpectedMessage(response, request.ExpectedMessage)
+	}
+	fmt.Fprintln(out, "Response:", printResp)
+	printChecks(out, cfg.Spec, request.Method, []types.JsonRpcRequest{request.JsonRpcRequest}, response)
+	return true
+}
+
+// printChecks prints the result schema, conformance and error data checks of a response, each on its own line,
+// so reports keep these findings apart from the scenario's expected code and message.
+func printChecks(out io.Writer, spec *openrpc.Spec, method string, requests []types.JsonRpcRequest, response string) {
+	for _, check := range []string{
+		CheckResultSchema(spec, method, response),
+		CheckConformance(requests, response),
+		CheckErrorData(response),
+	} {
+		if check != "" {
+			fmt.Fprintln(out, check)
 		}
-		var data interface{}
-		err = json.Unmarshal([]byte(response), &data)
-		if err != nil {
-			panic(err)
+	}
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// firstError extracts the error of a single-request batch response. ok is false if the response can't be parsed.
+func firstError(response string) (rpcErr *rpcError, ok bool) {
+	var batchResult []struct {
+		Error *rpcError `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(response), &batchResult); err != nil || len(batchResult) == 0 {
+		return nil, false
+	}
+	return batchResult[0].Error, true
+}
+
+// CheckExpectedCode compares the error code of a single-request batch response with the expected one.
+func CheckExpectedCode(response string, expected int) string {
+	rpcErr, ok := firstError(response)
+	if !ok {
+		return fmt.Sprintf("Expected code %d: FAIL (unparseable response)", expected)
+	}
+	if rpcErr == nil {
+		return fmt.Sprintf("Expected code %d: FAIL (got result)", expected)
+	}
+	if rpcErr.Code != expected {
+		return fmt.Sprintf("Expected code %d: FAIL (got %d)", expected, rpcErr.Code)
+	}
+	return fmt.Sprintf("Expected code %d: PASS", expected)
+}
+
+// CheckExpectedMessage checks that the error message of a single-request batch response contains expected.
+func CheckExpectedMessage(response string, expected string) string {
+	rpcErr, ok := firstError(response)
+	if !ok {
+		return fmt.Sprintf("Expected message %q: FAIL (unparseable response)", expected)
+	}
+	if rpcErr == nil {
+		return fmt.Sprintf("Expected message %q: FAIL (got result)", expected)
+	}
+	if !strings.Contains(rpcErr.Message, expected) {
+		return fmt.Sprintf("Expected message %q: FAIL (got %q)", expected, rpcErr.Message)
+	}
+	return fmt.Sprintf("Expected message %q: PASS", expected)
+}
+
+// CheckResultSchema validates every result of a response against the method's result schema in spec. It returns
+// an empty string when there is nothing to check: no spec, a method the spec does not define, or only errors.
+func CheckResultSchema(spec *openrpc.Spec, method string, response string) string {
+	if spec == nil || !spec.HasMethod(method) {
+		return ""
+	}
+
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(response), &entries); err != nil {
+		var single map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(response), &single); err != nil || single == nil {
+			return ""
 		}
+		entries = append(entries, single)
+	}
 
-		compactJSON, err := json.Marshal(data)
-		if err != nil {
-			panic(err)
+	checked := false
+	var violations []string
+	for _, entry := range entries {
+		raw, ok := entry["result"]
+		if !ok {
+			continue
 		}
+		var result interface{}
+		if err := json.Unmarshal(raw, &result); err != nil {
+			continue
+		}
+		checked = true
+		violations = append(violations, spec.ValidateResult(method, result)...)
+	}
 
-		fmt.Println("Response:", string(compactJSON))
+	switch {
+	case !checked:
+		return ""
+	case len(violations) > 0:
+		return fmt.Sprintf("Schema: FAIL (%s)", openrpc.Summary(violations))
+	default:
+		return "Schema: PASS"
 	}
 }
 
 func SendTransaction(ctx context.Context, client *ethclient.Client, cfg config.Config, scenario types.Scenario) error {
+	return sendTransaction(ctx, client, cfg, scenario, os.Stdout)
+}
+
+// sendTransaction is SendTransaction printing to out.
+func sendTransaction(ctx context.Context, client *ethclient.Client, cfg config.Config, scenario types.Scenario, out io.Writer) error {
 	// 1. Load default private key and addresses
 	if cfg.PrivateKey == "" {
 		return fmt.Errorf("private key is not set in config")
@@ -120,6 +264,17 @@ func SendTransaction(ctx context.Context, client *ethclient.Client, cfg config.C
 		batchTx, presendErr = scenario.PreSend(ctx, client, cfg, params)
 	}
 
+	// 5b. Submit the transaction sequence ahead of the scenario tx (for mempool limit scenarios)
+	var sequenceSummary string
+	var sequenceErr error
+	if scenario.Sequence != nil {
+		var rawTxs []string
+		rawTxs, sequenceErr = scenario.Sequence(ctx, client, cfg, params)
+		if sequenceErr == nil {
+			sequenceSummary, sequenceErr = SendSequence(cfg.Url, scenario, rawTxs)
+		}
+	}
+
 	// 6. Build transaction
 	tx := BuildTransaction(params)
 	// 7. Sign transaction
@@ -164,15 +319,26 @@ func SendTransaction(ctx context.Context, client *ethclient.Client, cfg config.C
 	if len(reqStr) > 1000 {
 		reqStr = reqStr[:1000] + "..."
 	}
-	fmt.Println("Scenario:", scenario.Desc, " - Request:", reqStr)
+	fmt.Fprintln(out, "Scenario:", scenario.Desc, " - Request:", reqStr)
 
 	// 12. Send transaction
 	response, err := SendRawJSONRPCRequest(cfg.Url, request)
 	if err != nil {
-		fmt.Println("Error:", err)
+		fmt.Fprintln(out, "Error:", err)
 		return nil // Continue to next scenario
 	}
 
+	// 12b. Submit the cleanup transactions, so later scenarios find the account as they would without this one
+	var cleanupSummary string
+	var cleanupErr error
+	if scenario.Cleanup != nil {
+		var rawTxs []string
+		rawTxs, cleanupErr = scenario.Cleanup(ctx, client, cfg, params)
+		if cleanupErr == nil {
+			cleanupSummary, cleanupErr = SendSequence(cfg.Url, scenario, rawTxs)
+		}
+	}
+
 	// 13. Print response
 	var data interface{}
 	var printResp string
@@ -185,8 +351,21 @@ func SendTransaction(ctx context.Context, client *ethclient.Client, cfg config.C
 	if presendErr != nil {
 		printResp += fmt.Sprintf(", PreSend Error: %v", presendErr)
 	}
+	if sequenceSummary != "" {
+		printResp += ", Sequence: " + sequenceSummary
+	}
+	if sequenceErr != nil {
+		printResp += fmt.Sprintf(", Sequence Error: %v", sequenceErr)
+	}
+	if cleanupSummary != "" {
+		printResp += ", Cleanup: " + cleanupSummary
+	}
+	if cleanupErr != nil {
+		printResp += fmt.Sprintf(", Cleanup Error: %v", cleanupErr)
+	}
 
-	fmt.Println("Response:", printResp)
+	fmt.Fprintln(out, "Response:", printResp)
+	printChecks(out, cfg.Spec, scenario.Method, request, response)
 	hashes, err := BatchResponseToTxHashes(response)
 	if err != nil {
 		return fmt.Errorf("failed to parse transaction hashes from response: %w", err)
@@ -199,6 +378,45 @@ func SendTransaction(ctx context.Context, client *ethclient.Client, cfg config.C
 	return nil
 }
 
+// SendSequence submits raw transactions in batches of SEQUENCE_BATCH_SIZE and summarises how many were
+// accepted and which errors were returned for the rest.
+func SendSequence(url string, scenario types.Scenario, rawTxs []string) (string, error) {
+	accepted := 0
+	rejected := make(map[string]int)
+
+	for start := 0; start < len(rawTxs); start += SEQUENCE_BATCH_SIZE {
+		end := min(start+SEQUENCE_BATCH_SIZE, len(rawTxs))
+		request := make([]types.JsonRpcRequest, 0, end-start)
+		for _, rawTx := range rawTxs[start:end] {
+			request = append(request, types.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      scenario.ID,
+				Method:  scenario.Method,
+				Params:  []interface{}{rawTx},
+			})
+		}
+
+		response, err := SendRawJSONRPCRequest(url, request)
+		if err != nil {
+			return "", err
+		}
+
+		var batchResult []map[string]interface{}
+		if err := json.Unmarshal([]byte(response), &batchResult); err != nil {
+			return "", fmt.Errorf("failed to parse sequence response: %w", err)
+		}
+		for _, res := range batchResult {
+			if errObj, ok := res["error"]; ok {
+				rejected[fmt.Sprint(errObj)]++
+			} else {
+				accepted++
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d/%d accepted, rejected: %v", accepted, len(rawTxs), rejected), nil
+}
+
 func WaitForTransaction(client *ethclient.Client, txHash string) (*gethTypes.Receipt, error) {
 	tx, isPending, err := client.TransactionByHash(context.Background(), common.HexToHash(txHash))
 	if err != nil {
@@ -217,15 +435,26 @@ func WaitForTransaction(client *ethclient.Client, txHash string) (*gethTypes.Rec
 	return receipt, nil
 }
 
+// BatchResponseToTxHashes returns the string results of a batch response in order. Errors and entries that are
+// not response objects are skipped, so the hashes do not line up with the requests.
 func BatchResponseToTxHashes(response string) ([]string, error) {
-	var batchResult []map[string]interface{}
+	var batchResult []json.RawMessage
 	if err := json.Unmarshal([]byte(response), &batchResult); err != nil {
 		return nil, fmt.Errorf("failed to parse batch response: %w", err)
 	}
+	if batchResult == nil {
+		return nil, fmt.Errorf("failed to parse batch response: got %s", response)
+	}
 
 	txHashes := make([]string, 0, len(batchResult))
-	for _, res := range batchResult {
-		if result, ok := res["result"].(string); ok {
+	for _, entry := range batchResult {
+		var res struct {
+			Result interface{} `json:"result"`
+		}
+		if err := json.Unmarshal(entry, &res); err != nil {
+			continue
+		}
+		if result, ok := res.Result.(string); ok {
 			txHashes = append(txHashes, result)
 		}
 	}
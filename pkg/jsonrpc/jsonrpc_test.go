@@ -0,0 +1,434 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/eth-error-tests/pkg/openrpc"
+	"github.com/eth-error-tests/pkg/types"
+)
+
+const testChainID = 1337
+
+// captureStdout returns everything fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+func startDevChain(t testing.TB, profile mockrpc.Profile) (*mockrpc.Server, config.Config) {
+	t.Helper()
+	server := mockrpc.NewServer(profile)
+	server.HandleDevChain(testChainID)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start mock server: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, config.Config{
+		Network:    "mock",
+		Url:        url,
+		PrivateKey: common.Bytes2Hex(crypto.FromECDSA(key)),
+		ChainID:    testChainID,
+		ToContract: "0x00000000000000000000000000000000000000aa",
+	}
+}
+
+func TestSendReq(t *testing.T) {
+	profile := mockrpc.Profile{
+		Methods: map[string][]mockrpc.Rule{
+			"eth_getBalance": {{Error: &mockrpc.Error{Code: -32602, Message: "invalid argument 0"}}},
+		},
+	}
+	_, cfg := startDevChain(t, profile)
+
+	requests := []types.Meta{
+		{
+			JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 1, Method: "eth_chainId", Params: []interface{}{}},
+			Desc:           "Proper request",
+		},
+		{
+			JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 2, Method: "eth_getBalance", Params: []interface{}{"0x1234"}},
+			Desc:           "Address wrong length",
+			ExpectedCode:   -32602,
+		},
+		{
+			JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 3, Method: "eth_getBalance", Params: []interface{}{}},
+			Desc:           "Missing address",
+			ExpectedCode:   -32000,
+		},
+	}
+
+	out := captureStdout(t, func() { SendReq(requests, cfg) })
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	want := []string{
+		`Scenario: Proper request  - Request: {"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`,
+		`Response: [{"id":1,"jsonrpc":"2.0","result":"0x539"}]`,
+		`Conformance: PASS`,
+		`Scenario: Address wrong length  - Request: {"jsonrpc":"2.0","id":2,"method":"eth_getBalance","params":["0x1234"]}`,
+		`Response: [{"error":{"code":-32602,"message":"invalid argument 0"},"id":2,"jsonrpc":"2.0"}], Expected code -32602: PASS`,
+		`Conformance: PASS`,
+		`Error data: absent`,
+		`Scenario: Missing address  - Request: {"jsonrpc":"2.0","id":3,"method":"eth_getBalance","params":[]}`,
+		`Response: [{"error":{"code":-32602,"message":"invalid argument 0"},"id":3,"jsonrpc":"2.0"}], Expected code -32000: FAIL (got -32602)`,
+		`Conformance: PASS`,
+		`Error data: absent`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d output lines, got %d:\n%s", len(want), len(lines), out)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestSendReqNonJSONResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "missing token", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	requests := []types.Meta{
+		{
+			JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 1, Method: "engine_exchangeCapabilities", Params: []interface{}{}},
+			Desc:           "Missing JWT",
+			ExpectedCode:   -38001,
+		},
+	}
+
+	out := captureStdout(t, func() { SendReq(requests, config.Config{Url: server.URL}) })
+	if !strings.Contains(out, "Response: missing token, Expected code -38001: FAIL (unparseable response)") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestCheckExpectedCode(t *testing.T) {
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{`[{"error":{"code":-32602,"message":"x"}}]`, "Expected code -32602: PASS"},
+		{`[{"error":{"code":-32000,"message":"x"}}]`, "Expected code -32602: FAIL (got -32000)"},
+		{`[{"result":"0x1"}]`, "Expected code -32602: FAIL (got result)"},
+		{`[]`, "Expected code -32602: FAIL (unparseable response)"},
+		{`Unauthorized`, "Expected code -32602: FAIL (unparseable response)"},
+	}
+
+	for _, tt := range tests {
+		if got := CheckExpectedCode(tt.response, -32602); got != tt.want {
+			t.Errorf("CheckExpectedCode(%s) = %s, want %s", tt.response, got, tt.want)
+		}
+	}
+}
+
+func TestCheckExpectedMessage(t *testing.T) {
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{`[{"error":{"code":-32602,"message":"invalid argument 0"}}]`, `Expected message "invalid": PASS`},
+		{`[{"error":{"code":-32602,"message":"missing value"}}]`, `Expected message "invalid": FAIL (got "missing value")`},
+		{`[{"result":"0x1"}]`, `Expected message "invalid": FAIL (got result)`},
+		{`Unauthorized`, `Expected message "invalid": FAIL (unparseable response)`},
+	}
+
+	for _, tt := range tests {
+		if got := CheckExpectedMessage(tt.response, "invalid"); got != tt.want {
+			t.Errorf("CheckExpectedMessage(%s) = %s, want %s", tt.response, got, tt.want)
+		}
+	}
+}
+
+// sentTransactions decodes every raw transaction the mock server received.
+func sentTransactions(t *testing.T, server *mockrpc.Server) []*gethTypes.Transaction {
+	t.Helper()
+	var txs []*gethTypes.Transaction
+	for _, req := range server.Requests() {
+		if req.Method != "eth_sendRawTransaction" {
+			continue
+		}
+		var params []hexutil.Bytes
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			t.Fatalf("failed to decode params: %v", err)
+		}
+		tx := new(gethTypes.Transaction)
+		if err := tx.UnmarshalBinary(params[0]); err != nil {
+			t.Fatalf("failed to decode transaction: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func TestCheckResultSchema(t *testing.T) {
+	spec := openrpc.Default()
+
+	tests := []struct {
+		method   string
+		response string
+		want     string
+	}{
+		{"eth_estimateGas", `[{"jsonrpc":"2.0","id":1,"result":"0x5839"}]`, "Schema: PASS"},
+		{"eth_estimateGas", `{"jsonrpc":"2.0","id":1,"result":"0x05839"}`, `Schema: FAIL (result: "0x05839" does not match`},
+		{"eth_estimateGas", `[{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted"}}]`, ""},
+		{"eth_sendRawTransaction", `[{"result":"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"},{"result":"0xaa"}]`, "Schema: FAIL"},
+		{"eth_getTransactionReceipt", `[{"jsonrpc":"2.0","id":1,"result":null}]`, "Schema: PASS"},
+		{"net_version", `[{"jsonrpc":"2.0","id":1,"result":"1337"}]`, ""},
+		{"eth_estimateGas", `Unauthorized`, ""},
+	}
+
+	for _, tt := range tests {
+		got := CheckResultSchema(spec, tt.method, tt.response)
+		if (tt.want == "" && got != "") || !strings.HasPrefix(got, tt.want) {
+			t.Errorf("CheckResultSchema(%s, %s) = %q, want prefix %q", tt.method, tt.response, got, tt.want)
+		}
+	}
+
+	if got := CheckResultSchema(nil, "eth_estimateGas", `[{"result":"0x05"}]`); got != "" {
+		t.Errorf("expected no check without a spec, got %q", got)
+	}
+}
+
+func TestSendReqValidatesResults(t *testing.T) {
+	profile := mockrpc.Profile{
+		Methods: map[string][]mockrpc.Rule{
+			"eth_gasPrice": {{Result: json.RawMessage(`"0x03b9aca00"`)}},
+		},
+	}
+	_, cfg := startDevChain(t, profile)
+	cfg.Spec = openrpc.Default()
+
+	requests := []types.Meta{
+		{JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 1, Method: "eth_getBlockByNumber", Params: []interface{}{"latest", false}}, Desc: "Block"},
+		{JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: 2, Method: "eth_gasPrice", Params: []interface{}{}}, Desc: "Gas price"},
+	}
+
+	out := captureStdout(t, func() { SendReq(requests, cfg) })
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("expected 8 output lines, got %d:\n%s", len(lines), out)
+	}
+	if lines[2] != "Schema: PASS" {
+		t.Errorf("mock block should match the schema: %s", lines[2])
+	}
+	if !strings.HasPrefix(lines[6], `Schema: FAIL (result: "0x03b9aca00" does not match hex encoded unsigned integer`) {
+		t.Errorf("leading zero quantity should fail the schema: %s", lines[6])
+	}
+}
+
+func TestSendTransaction(t *testing.T) {
+	server, cfg := startDevChain(t, mockrpc.Profile{})
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	scenario := types.Scenario{
+		ID:        1,
+		Desc:      "NONCE_TOO_HIGH",
+		Method:    "eth_sendRawTransaction",
+		Modifiers: []types.Modifier{NonceModifier(7, nil)},
+	}
+
+	var sendErr error
+	out := captureStdout(t, func() { sendErr = SendTransaction(context.Background(), client, cfg, scenario) })
+	if sendErr != nil {
+		t.Fatalf("unexpected error: %v", sendErr)
+	}
+
+	txs := sentTransactions(t, server)
+	if len(txs) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(txs))
+	}
+	tx := txs[0]
+	if tx.Nonce() != 7 {
+		t.Errorf("nonce = %d, want 7", tx.Nonce())
+	}
+	if tx.Type() != gethTypes.DynamicFeeTxType {
+		t.Errorf("type = %d, want dynamic fee tx", tx.Type())
+	}
+	if tx.ChainId().Int64() != testChainID {
+		t.Errorf("chain id = %d, want %d", tx.ChainId().Int64(), testChainID)
+	}
+	if !strings.Contains(out, "Scenario: NONCE_TOO_HIGH  - Request:") || !strings.Contains(out, `"result":"`+tx.Hash().Hex()+`"`) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestSendTransactionBatchWithPreSend(t *testing.T) {
+	server, cfg := startDevChain(t, mockrpc.Profile{})
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	scenario := types.Scenario{
+		ID:       2,
+		Desc:     "REPLACEMENT_TRANSACTION_UNDERPRICED",
+		Method:   "eth_sendRawTransaction",
+		UseBatch: true,
+		PreSend: func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *types.TxParams) (string, error) {
+			signed, err := SignTransaction(BuildTransaction(params), params)
+			if err != nil {
+				return "", err
+			}
+			raw, err := signed.MarshalBinary()
+			if err != nil {
+				return "", err
+			}
+			return hexutil.Encode(raw), nil
+		},
+	}
+
+	var sendErr error
+	captureStdout(t, func() { sendErr = SendTransaction(context.Background(), client, cfg, scenario) })
+	if sendErr != nil {
+		t.Fatalf("unexpected error: %v", sendErr)
+	}
+
+	if txs := sentTransactions(t, server); len(txs) != 2 {
+		t.Fatalf("expected the scenario and pre-send transactions in one batch, got %d", len(txs))
+	}
+}
+
+func TestSendTransactionSequenceAndCleanup(t *testing.T) {
+	server, cfg := startDevChain(t, mockrpc.Profile{})
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	encode := func(params types.TxParams, nonce uint64) (string, error) {
+		params.Nonce = nonce
+		signed, err := SignTransaction(BuildTransaction(&params), &params)
+		if err != nil {
+			return "", err
+		}
+		raw, err := signed.MarshalBinary()
+		return hexutil.Encode(raw), err
+	}
+	// Two txs queued behind a gap at the pending nonce 0, the scenario tx after them and the gap filled last
+	scenario := types.Scenario{
+		ID:     3,
+		Desc:   "TXPOOL_QUEUE_LIMIT",
+		Method: "eth_sendRawTransaction",
+		Sequence: func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *types.TxParams) ([]string, error) {
+			var rawTxs []string
+			for nonce := uint64(1); nonce <= 2; nonce++ {
+				rawTx, err := encode(*params, nonce)
+				if err != nil {
+					return nil, err
+				}
+				rawTxs = append(rawTxs, rawTx)
+			}
+			params.Nonce = 3
+			return rawTxs, nil
+		},
+		Cleanup: func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *types.TxParams) ([]string, error) {
+			rawTx, err := encode(*params, 0)
+			return []string{rawTx}, err
+		},
+	}
+
+	var sendErr error
+	out := captureStdout(t, func() { sendErr = SendTransaction(context.Background(), client, cfg, scenario) })
+	if sendErr != nil {
+		t.Fatalf("unexpected error: %v", sendErr)
+	}
+
+	var nonces []uint64
+	for _, tx := range sentTransactions(t, server) {
+		nonces = append(nonces, tx.Nonce())
+	}
+	if fmt.Sprint(nonces) != "[1 2 3 0]" {
+		t.Errorf("nonces sent = %v, want the sequence, the scenario tx, then the cleanup", nonces)
+	}
+	if !strings.Contains(out, "Sequence: 2/2 accepted") || !strings.Contains(out, "Cleanup: 1/1 accepted") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestSendTransactionRequiresPrivateKey(t *testing.T) {
+	if err := SendTransaction(context.Background(), nil, config.Config{}, types.Scenario{}); err == nil {
+		t.Error("expected an error without a private key")
+	}
+}
+
+func TestBatchResponseToTxHashes(t *testing.T) {
+	hashes, err := BatchResponseToTxHashes(`[{"result":"0xaa"},{"error":{"code":-32000,"message":"nonce too low"}},{"result":"0xbb"}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hashes) != 2 || hashes[0] != "0xaa" || hashes[1] != "0xbb" {
+		t.Errorf("unexpected hashes: %v", hashes)
+	}
+
+	if _, err := BatchResponseToTxHashes(`{"error":{"code":-32600,"message":"batch too large"}}`); err == nil {
+		t.Error("expected an error for a non-batch response")
+	}
+}
+
+func FuzzBatchResponseToTxHashes(f *testing.F) {
+	f.Add(`[{"result":"0xaa"},{"error":{"code":-32000,"message":"nonce too low"}},{"result":"0xbb"}]`)
+	f.Add(`{"error":{"code":-32600,"message":"batch too large"}}`)
+	f.Add(`[1,"0xaa",null,[],{"result":{"hash":"0xaa"}},{"result":null}]`)
+	f.Add(`null`)
+	f.Add(`[]`)
+
+	f.Fuzz(func(t *testing.T, response string) {
+		hashes, err := BatchResponseToTxHashes(response)
+		if err != nil {
+			return
+		}
+
+		var entries []json.RawMessage
+		if err := json.Unmarshal([]byte(response), &entries); err != nil || entries == nil {
+			t.Fatalf("accepted a response that is not a batch: %q", response)
+		}
+		if len(hashes) > len(entries) {
+			t.Fatalf("%d hashes from %d entries", len(hashes), len(entries))
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NewEngineJWT creates an HS256 token for the Engine API as specified in
+// https://github.com/ethereum/execution-apis/blob/main/src/engine/authentication.md
+func NewEngineJWT(secretHex string, issuedAt time.Time) (string, error) {
+	secret := common.FromHex(secretHex)
+	if len(secret) != 32 {
+		return "", fmt.Errorf("jwt secret must be 32 bytes, got %d", len(secret))
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]int64{"iat": issuedAt.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,230 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/eth-error-tests/pkg/config"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// modifierFactory builds a modifier from the arguments given in a scenario file.
+type modifierFactory func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error)
+
+// namedModifiers exposes the modifier library by name. Numeric arguments are absolute values, or relative to
+// the default when given as a string starting with "+" or "-" (e.g. "+100" for NonceModifier).
+var namedModifiers = map[string]modifierFactory{
+	"NonceModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := uintArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return NonceModifier(value, relativeUint(delta)), nil
+	},
+	"GasLimitModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := uintArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		var transform func(cfg config.Config, current uint64) uint64
+		if delta != nil {
+			transform = func(cfg config.Config, current uint64) uint64 { return relativeUint(delta)(current) }
+		}
+		return GasLimitModifier(cfg, value, transform), nil
+	},
+	"GasPriceModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := bigArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return GasPriceModifier(value, relativeBig(delta)), nil
+	},
+	"GasTipCapModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := bigArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return GasTipCapModifier(value, relativeBig(delta)), nil
+	},
+	"GasFeeCapModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := bigArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return GasFeeCapModifier(value, relativeBig(delta)), nil
+	},
+	"ValueModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := bigArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		if delta != nil {
+			return nil, fmt.Errorf("relative values are not supported, use ValueFromBalanceModifier")
+		}
+		return ValueModifier(value), nil
+	},
+	"ValueFromBalanceModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := bigArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		if delta != nil {
+			value = delta
+		}
+		if !value.IsInt64() {
+			return nil, fmt.Errorf("offset %s out of range", value)
+		}
+		return ValueFromBalanceModifier(value.Int64()), nil
+	},
+	"DataSizeModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		value, delta, err := uintArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		if delta != nil || value > math.MaxInt32 {
+			return nil, fmt.Errorf("size must be an absolute byte count")
+		}
+		return DataSizeModifier(int(value)), nil
+	},
+	"ToAddressModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		address, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return ToAddressModifier(cfg, address, nil), nil
+	},
+	"PrivateKeyModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		privateKey, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return PrivateKeyModifier(strings.TrimPrefix(privateKey, "0x")), nil
+	},
+	"InvalidFunctionSigModifier": func(cfg config.Config, args []interface{}) (pkgTypes.Modifier, error) {
+		functionSig, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		argValue, delta, err := uintArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		if delta != nil {
+			return nil, fmt.Errorf("argument value must be absolute")
+		}
+		return InvalidFunctionSigModifier(functionSig, argValue), nil
+	},
+}
+
+// ModifierByName builds one of the library modifiers from its Go name and scenario file arguments.
+func ModifierByName(cfg config.Config, name string, args []interface{}) (pkgTypes.Modifier, error) {
+	factory, ok := namedModifiers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown modifier %q, available: %s", name, strings.Join(ModifierNames(), ", "))
+	}
+
+	modifier, err := factory(cfg, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return modifier, nil
+}
+
+// ModifierNames lists the modifiers available to ModifierByName.
+func ModifierNames() []string {
+	names := make([]string, 0, len(namedModifiers))
+	for name := range namedModifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	if i >= len(args) {
+		return "", fmt.Errorf("missing argument %d", i)
+	}
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d must be a string, got %v", i, args[i])
+	}
+	return s, nil
+}
+
+// bigArg parses argument i as an absolute value, or as a signed delta when it is a string starting with + or -.
+func bigArg(args []interface{}, i int) (value *big.Int, delta *big.Int, err error) {
+	if i >= len(args) {
+		return nil, nil, fmt.Errorf("missing argument %d", i)
+	}
+
+	switch v := args[i].(type) {
+	case int:
+		return big.NewInt(int64(v)), nil, nil
+	case int64:
+		return big.NewInt(v), nil, nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, nil, fmt.Errorf("argument %d must be an integer, got %v", i, v)
+		}
+		value, _ := big.NewFloat(v).Int(nil)
+		return value, nil, nil
+	case string:
+		relative := strings.HasPrefix(v, "+") || strings.HasPrefix(v, "-")
+		parsed, ok := new(big.Int).SetString(strings.TrimPrefix(v, "+"), 0)
+		if !ok {
+			return nil, nil, fmt.Errorf("argument %d: invalid number %q", i, v)
+		}
+		if relative {
+			return nil, parsed, nil
+		}
+		return parsed, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("argument %d must be a number, got %v", i, v)
+	}
+}
+
+func uintArg(args []interface{}, i int) (value uint64, delta *big.Int, err error) {
+	bigValue, delta, err := bigArg(args, i)
+	if err != nil || delta != nil {
+		return 0, delta, err
+	}
+	if !bigValue.IsUint64() {
+		return 0, nil, fmt.Errorf("argument %d: %s out of range", i, bigValue)
+	}
+	return bigValue.Uint64(), nil, nil
+}
+
+// relativeUint returns a transform adding delta (clamped at 0), or nil for absolute values.
+func relativeUint(delta *big.Int) func(current uint64) uint64 {
+	if delta == nil {
+		return nil
+	}
+	return func(current uint64) uint64 {
+		result := new(big.Int).Add(new(big.Int).SetUint64(current), delta)
+		if result.Sign() < 0 {
+			return 0
+		}
+		if !result.IsUint64() {
+			return math.MaxUint64
+		}
+		return result.Uint64()
+	}
+}
+
+// relativeBig returns a transform adding delta, or nil for absolute values.
+func relativeBig(delta *big.Int) func(current *big.Int) *big.Int {
+	if delta == nil {
+		return nil
+	}
+	return func(current *big.Int) *big.Int {
+		if current == nil {
+			current = new(big.Int)
+		}
+		return new(big.Int).Add(current, delta)
+	}
+}
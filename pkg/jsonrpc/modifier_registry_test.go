@@ -0,0 +1,153 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func TestModifierByName(t *testing.T) {
+	defaults := func() *pkgTypes.TxParams {
+		return &pkgTypes.TxParams{
+			Nonce:     10,
+			Gas:       50_000,
+			GasPrice:  big.NewInt(1_000),
+			GasTipCap: big.NewInt(100),
+			GasFeeCap: big.NewInt(2_000),
+			IsDynamic: true,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		args  []interface{}
+		check func(p *pkgTypes.TxParams) bool
+	}{
+		{"NonceModifier", []interface{}{0}, func(p *pkgTypes.TxParams) bool { return p.Nonce == 0 }},
+		{"NonceModifier", []interface{}{"+100"}, func(p *pkgTypes.TxParams) bool { return p.Nonce == 110 }},
+		{"NonceModifier", []interface{}{"-20"}, func(p *pkgTypes.TxParams) bool { return p.Nonce == 0 }},
+		{"GasLimitModifier", []interface{}{20000}, func(p *pkgTypes.TxParams) bool { return p.Gas == 20_000 }},
+		{"GasLimitModifier", []interface{}{"-1"}, func(p *pkgTypes.TxParams) bool { return p.Gas == 49_999 }},
+		{"GasPriceModifier", []interface{}{"200000000000"}, func(p *pkgTypes.TxParams) bool {
+			return p.GasPrice.Cmp(big.NewInt(200_000_000_000)) == 0 && !p.IsDynamic
+		}},
+		{"GasTipCapModifier", []interface{}{"0x10"}, func(p *pkgTypes.TxParams) bool { return p.GasTipCap.Int64() == 16 }},
+		{"GasFeeCapModifier", []interface{}{"+1"}, func(p *pkgTypes.TxParams) bool { return p.GasFeeCap.Int64() == 2_001 }},
+		{"ValueModifier", []interface{}{5}, func(p *pkgTypes.TxParams) bool { return p.Value.Int64() == 5 }},
+		{"DataSizeModifier", []interface{}{128}, func(p *pkgTypes.TxParams) bool { return len(p.Data) == 128 }},
+		{"ToAddressModifier", []interface{}{"0x00000000000000000000000000000000000000aa"}, func(p *pkgTypes.TxParams) bool {
+			return p.To != nil && *p.To == common.HexToAddress("0xaa")
+		}},
+		{"InvalidFunctionSigModifier", []interface{}{"invalidFunction(uint256)", 20}, func(p *pkgTypes.TxParams) bool { return len(p.Data) == 36 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modifier, err := ModifierByName(config.Config{}, tt.name, tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			params := defaults()
+			if err := modifier(context.Background(), nil, params); err != nil {
+				t.Fatalf("modifier failed: %v", err)
+			}
+			if !tt.check(params) {
+				t.Errorf("unexpected params after %s%v: %+v", tt.name, tt.args, params)
+			}
+		})
+	}
+}
+
+func TestModifierByNameErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []interface{}
+		wantErr string
+	}{
+		{"UnknownModifier", nil, "unknown modifier"},
+		{"NonceModifier", nil, "missing argument 0"},
+		{"NonceModifier", []interface{}{"abc"}, "invalid number"},
+		{"NonceModifier", []interface{}{-1}, "out of range"},
+		{"NonceModifier", []interface{}{1.5}, "must be an integer"},
+		{"DataSizeModifier", []interface{}{"+1"}, "absolute byte count"},
+		{"ToAddressModifier", []interface{}{1}, "must be a string"},
+		{"ValueModifier", []interface{}{"+1"}, "relative values are not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ModifierByName(config.Config{}, tt.name, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// maxFuzzDataSize keeps DataSizeModifier inputs from allocating gigabytes per iteration.
+const maxFuzzDataSize = 1 << 20
+
+func FuzzModifierChain(f *testing.F) {
+	f.Add(`[{"name":"NonceModifier","args":["+100"]},{"name":"GasLimitModifier","args":[21000]}]`)
+	f.Add(`[{"name":"GasPriceModifier","args":["-1000000000000"]},{"name":"ValueFromBalanceModifier","args":[1]}]`)
+	f.Add(`[{"name":"GasTipCapModifier","args":["+1"]},{"name":"GasFeeCapModifier","args":["0x0"]},{"name":"DataSizeModifier","args":[1024]}]`)
+	f.Add(`[{"name":"ToAddressModifier","args":["not an address"]},{"name":"InvalidFunctionSigModifier","args":["f()",1]}]`)
+	f.Add(`[{"name":"PrivateKeyModifier","args":["0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"]},{"name":"ValueModifier","args":["115792089237316195423570985008687907853269984665640564039457584007913129639936"]}]`)
+
+	_, cfg := startDevChain(f, mockrpc.Profile{})
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Cleanup(client.Close)
+	key, err := crypto.HexToECDSA(cfg.PrivateKey)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, chain string) {
+		var steps []struct {
+			Name string        `json:"name"`
+			Args []interface{} `json:"args"`
+		}
+		if err := json.Unmarshal([]byte(chain), &steps); err != nil {
+			return
+		}
+
+		ctx := context.Background()
+		params, err := NewTxParamsFromDefaults(ctx, client, cfg, key, common.HexToAddress(cfg.ToContract), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, step := range steps {
+			if size, ok := firstArg(step.Args).(float64); ok && step.Name == "DataSizeModifier" && size > maxFuzzDataSize {
+				return
+			}
+			modifier, err := ModifierByName(cfg, step.Name, step.Args)
+			if err != nil {
+				return
+			}
+			if err := modifier(ctx, client, params); err != nil {
+				return
+			}
+		}
+
+		checkSignedTransaction(t, cfg.Url, params)
+	})
+}
+
+func firstArg(args []interface{}) interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
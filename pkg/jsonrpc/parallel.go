@@ -0,0 +1,299 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/types"
+)
+
+// FUNDING_TIMEOUT bounds the wait for the sender top-ups, which unlike scenario transactions must be mined
+const FUNDING_TIMEOUT = 2 * time.Minute
+
+// stateChangingPrefixes are the methods whose requests change node state (transactions, filters, accounts,
+// the Engine API's fork choice), so their scenarios keep their serial order.
+var stateChangingPrefixes = []string{
+	"eth_send", "eth_new", "eth_uninstallFilter", "eth_getFilterChanges",
+	"personal_", "engine_", "miner_", "admin_", "debug_set",
+}
+
+// IsReadOnly reports whether requests calling method can run concurrently without affecting each other.
+func IsReadOnly(method string) bool {
+	for _, prefix := range stateChangingPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// runOrdered runs task for the indexes 0..n-1 on up to lanes goroutines: index i runs on lane i % lanes after
+// the lane's previous indexes. Indexes for which exclusive returns true (nil for none) run one at a time once
+// the lanes have drained, still on lane i % lanes. Every task writes to its own buffer and the buffers are copied
+// to stdout in index order as soon as they are complete, so the output is that of a serial run. Once a task
+// returns false the output ends there and tasks not yet started are skipped. With a single lane the tasks write
+// to stdout directly.
+func runOrdered(n, lanes int, exclusive func(i int) bool, task func(i, lane int, out io.Writer) bool) {
+	if lanes <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			if !task(i, 0, os.Stdout) {
+				return
+			}
+		}
+		return
+	}
+	lanes = min(lanes, n)
+	if exclusive == nil {
+		exclusive = func(int) bool { return false }
+	}
+
+	buffers := make([]bytes.Buffer, n)
+	ok := make([]bool, n)
+	done := make([]chan struct{}, n)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var stopped atomic.Bool
+	run := func(i int) {
+		if !stopped.Load() {
+			ok[i] = task(i, i%lanes, &buffers[i])
+		}
+		close(done[i])
+	}
+
+	var wg sync.WaitGroup
+	for lane := 0; lane < lanes; lane++ {
+		wg.Add(1)
+		go func(lane int) {
+			defer wg.Done()
+			for i := lane; i < n; i += lanes {
+				if !exclusive(i) {
+					run(i)
+				}
+			}
+		}(lane)
+	}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		wg.Wait()
+		for i := 0; i < n; i++ {
+			if exclusive(i) {
+				run(i)
+			}
+		}
+	}()
+	defer func() { <-finished }()
+
+	for i := 0; i < n; i++ {
+		<-done[i]
+		os.Stdout.Write(buffers[i].Bytes())
+		if !ok[i] {
+			stopped.Store(true)
+			return
+		}
+	}
+}
+
+// SendTransactions sends the scenarios with SendTransaction, each followed by an empty line. With cfg.Parallel > 1
+// and cfg.Senders set, the scenarios are spread over the senders: scenario i is sent from sender i % lanes once
+// the sender's previous scenarios are done, so every account sees the same sequence of nonces on every run and
+// no two scenarios share a nonce. Sequence scenarios fill the shared txpool, so they run alone once the other
+// scenarios are done.
+func SendTransactions(ctx context.Context, client *ethclient.Client, cfg config.Config, scenarios []types.Scenario) {
+	lanes := min(cfg.Parallel, len(cfg.Senders))
+	laneConfigs := []config.Config{cfg}
+	if lanes > 1 {
+		laneConfigs = make([]config.Config, lanes)
+		for lane := range laneConfigs {
+			var err error
+			if laneConfigs[lane], err = WithSender(cfg, cfg.Senders[lane]); err != nil {
+				fmt.Println("Error loading sender account:", err)
+				return
+			}
+		}
+	}
+
+	exclusive := func(i int) bool { return scenarios[i].Sequence != nil }
+	runOrdered(len(scenarios), lanes, exclusive, func(i, lane int, out io.Writer) bool {
+		scenario := scenarios[i]
+		if err := sendTransaction(ctx, client, laneConfigs[lane], scenario, out); err != nil {
+			fmt.Fprintf(out, "Error executing scenario %d (%s): %v\n", scenario.ID, scenario.Desc, err)
+		}
+		fmt.Fprintln(out)
+		return true
+	})
+}
+
+// WithSender returns a copy of cfg that signs with privateKey.
+func WithSender(cfg config.Config, privateKey string) (config.Config, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return cfg, fmt.Errorf("error loading private key: %w", err)
+	}
+	cfg.PrivateKey = privateKey
+	cfg.From = crypto.PubkeyToAddress(key.PublicKey).Hex()
+	return cfg, nil
+}
+
+// DeriveSenders derives n private keys from privateKey, the i-th being keccak256(privateKey || i), so every run
+// reuses the same sender accounts.
+func DeriveSenders(privateKey string, n int) ([]string, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error loading private key: %w", err)
+	}
+	seed := crypto.FromECDSA(key)
+
+	senders := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		derived, err := crypto.ToECDSA(crypto.Keccak256(seed, binary.BigEndian.AppendUint64(nil, uint64(i))))
+		if err != nil {
+			return nil, fmt.Errorf("error deriving sender %d: %w", i, err)
+		}
+		senders = append(senders, common.Bytes2Hex(crypto.FromECDSA(derived)))
+	}
+	return senders, nil
+}
+
+// FundSenders tops up the senders from cfg's account so that each holds an equal share of half its balance, and
+// waits until the top-ups are mined. Senders already holding their share are left alone.
+func FundSenders(ctx context.Context, client *ethclient.Client, cfg config.Config, senders []string) error {
+	if len(senders) == 0 {
+		return nil
+	}
+	privateKey, err := crypto.HexToECDSA(cfg.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("error loading private key: %w", err)
+	}
+
+	balance, err := client.BalanceAt(ctx, crypto.PubkeyToAddress(privateKey.PublicKey), nil)
+	if err != nil {
+		return fmt.Errorf("error getting balance: %w", err)
+	}
+	share := new(big.Int).Div(balance, big.NewInt(int64(2*len(senders))))
+
+	var params *types.TxParams
+	var lastTopUp *gethTypes.Transaction
+	for _, sender := range senders {
+		senderCfg, err := WithSender(cfg, sender)
+		if err != nil {
+			return err
+		}
+		address := common.HexToAddress(senderCfg.From)
+		senderBalance, err := client.BalanceAt(ctx, address, nil)
+		if err != nil {
+			return fmt.Errorf("error getting balance of %s: %w", address.Hex(), err)
+		}
+		if senderBalance.Cmp(share) >= 0 {
+			continue
+		}
+
+		// Top-ups are sent back to back, so the nonce is only fetched for the first one
+		if params == nil {
+			if params, err = NewTxParamsFromDefaults(ctx, client, cfg, privateKey, address, nil); err != nil {
+				return fmt.Errorf("error building tx params: %w", err)
+			}
+		} else {
+			params.Nonce++
+		}
+		params.To = &address
+		params.Gas = 21_000
+		params.Value = new(big.Int).Sub(share, senderBalance)
+
+		signedTx, err := SignTransaction(BuildTransaction(params), params)
+		if err != nil {
+			return fmt.Errorf("error signing transaction: %w", err)
+		}
+		if err := client.SendTransaction(ctx, signedTx); err != nil {
+			return fmt.Errorf("error funding %s: %w", address.Hex(), err)
+		}
+		lastTopUp = signedTx
+	}
+	if lastTopUp == nil {
+		return nil
+	}
+
+	// The top-ups share a nonce sequence, so the last one being mined implies the others are
+	ctx, cancel := context.WithTimeout(ctx, FUNDING_TIMEOUT)
+	defer cancel()
+	return waitMined(ctx, client, lastTopUp)
+}
+
+// AdvanceSenders has every sender that never sent a transaction send one to itself, and waits until they are
+// mined. Nonce-relative scenarios (e.g. "NONCE_TOO_LOW - previous nonce") then find the same history on
+// the first run as on later ones. The senders must be funded.
+func AdvanceSenders(ctx context.Context, client *ethclient.Client, cfg config.Config, senders []string) error {
+	var sent []*gethTypes.Transaction
+	for _, sender := range senders {
+		senderCfg, err := WithSender(cfg, sender)
+		if err != nil {
+			return err
+		}
+		privateKey, err := crypto.HexToECDSA(sender)
+		if err != nil {
+			return fmt.Errorf("error loading private key: %w", err)
+		}
+		address := common.HexToAddress(senderCfg.From)
+
+		nonce, err := client.PendingNonceAt(ctx, address)
+		if err != nil {
+			return fmt.Errorf("error getting nonce of %s: %w", address.Hex(), err)
+		}
+		if nonce > 0 {
+			continue
+		}
+
+		params, err := NewTxParamsFromDefaults(ctx, client, senderCfg, privateKey, address, nil)
+		if err != nil {
+			return fmt.Errorf("error building tx params: %w", err)
+		}
+		params.Gas = 21_000
+		signedTx, err := SignTransaction(BuildTransaction(params), params)
+		if err != nil {
+			return fmt.Errorf("error signing transaction: %w", err)
+		}
+		if err := client.SendTransaction(ctx, signedTx); err != nil {
+			return fmt.Errorf("error advancing the nonce of %s: %w", address.Hex(), err)
+		}
+		sent = append(sent, signedTx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, FUNDING_TIMEOUT)
+	defer cancel()
+	for _, tx := range sent {
+		if err := waitMined(ctx, client, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitMined waits until tx is mined and checks that it succeeded.
+func waitMined(ctx context.Context, client *ethclient.Client, tx *gethTypes.Transaction) error {
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != gethTypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s failed", tx.Hash().Hex())
+	}
+	return nil
+}
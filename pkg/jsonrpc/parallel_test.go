@@ -0,0 +1,323 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/eth-error-tests/pkg/types"
+)
+
+func TestIsReadOnly(t *testing.T) {
+	tests := map[string]bool{
+		"eth_getBalance":         true,
+		"eth_call":               true,
+		"debug_traceCall":        true,
+		"eth_sendRawTransaction": false,
+		"eth_sendTransaction":    false,
+		"eth_newFilter":          false,
+		"eth_getFilterChanges":   false,
+		"engine_newPayloadV3":    false,
+		"personal_unlockAccount": false,
+	}
+	for method, want := range tests {
+		if got := IsReadOnly(method); got != want {
+			t.Errorf("IsReadOnly(%q) = %t, want %t", method, got, want)
+		}
+	}
+}
+
+func TestRunOrdered(t *testing.T) {
+	const n = 6
+	task := func(stopAt int) func(i, lane int, out io.Writer) bool {
+		return func(i, lane int, out io.Writer) bool {
+			// Later indexes finish first
+			time.Sleep(time.Duration(n-i) * 5 * time.Millisecond)
+			fmt.Fprintf(out, "%d\n", i)
+			return i != stopAt
+		}
+	}
+
+	for _, lanes := range []int{1, 3, 10} {
+		output := captureStdout(t, func() { runOrdered(n, lanes, nil, task(-1)) })
+		if output != "0\n1\n2\n3\n4\n5\n" {
+			t.Errorf("lanes %d: output = %q, want the indexes in order", lanes, output)
+		}
+
+		output = captureStdout(t, func() { runOrdered(n, lanes, nil, task(2)) })
+		if output != "0\n1\n2\n" {
+			t.Errorf("lanes %d: output = %q, want it to end at the failing task", lanes, output)
+		}
+	}
+}
+
+func TestRunOrderedExclusive(t *testing.T) {
+	const n = 7
+	exclusive := func(i int) bool { return i == 1 || i == 4 }
+
+	var running, finished atomic.Int32
+	output := captureStdout(t, func() {
+		runOrdered(n, 3, exclusive, func(i, lane int, out io.Writer) bool {
+			active := running.Add(1)
+			defer running.Add(-1)
+			if lane != i%3 {
+				t.Errorf("index %d ran on lane %d", i, lane)
+			}
+			if exclusive(i) && (active != 1 || finished.Load() < n-2) {
+				t.Errorf("exclusive index %d ran alongside others or before the lanes drained", i)
+			}
+			time.Sleep(5 * time.Millisecond)
+			if !exclusive(i) {
+				finished.Add(1)
+			}
+			fmt.Fprintf(out, "%d\n", i)
+			return true
+		})
+	})
+	if output != "0\n1\n2\n3\n4\n5\n6\n" {
+		t.Errorf("output = %q, want the indexes in order", output)
+	}
+}
+
+func TestSendReqParallelMatchesSerial(t *testing.T) {
+	_, cfg := startDevChain(t, mockrpc.Profile{})
+
+	var requests []types.Meta
+	for i, method := range []string{"eth_chainId", "eth_getBalance", "eth_blockNumber", "eth_gasPrice", "eth_doesNotExist"} {
+		requests = append(requests, types.Meta{
+			JsonRpcRequest: types.JsonRpcRequest{JsonRpc: "2.0", Id: i + 1, Method: method, Params: []interface{}{}},
+			Desc:           method,
+		})
+	}
+
+	serial := captureStdout(t, func() { SendReq(requests, cfg) })
+	cfg.Parallel = 4
+	parallel := captureStdout(t, func() { SendReq(requests, cfg) })
+	if parallel != serial {
+		t.Errorf("parallel output differs from the serial one:\n%s\nwant:\n%s", parallel, serial)
+	}
+	if strings.Count(serial, "Response:") != len(requests) {
+		t.Errorf("expected a response per request:\n%s", serial)
+	}
+}
+
+func TestDeriveSenders(t *testing.T) {
+	_, cfg := startDevChain(t, mockrpc.Profile{})
+
+	senders, err := DeriveSenders(cfg.PrivateKey, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := DeriveSenders(cfg.PrivateKey, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(senders) != 3 || again[0] != senders[0] || again[1] != senders[1] {
+		t.Errorf("derivation is not deterministic: %v, %v", senders, again)
+	}
+
+	seen := map[string]bool{cfg.PrivateKey: true}
+	for _, sender := range senders {
+		if seen[sender] {
+			t.Errorf("sender key %s repeats", sender)
+		}
+		seen[sender] = true
+	}
+
+	if _, err := DeriveSenders("not a key", 1); err == nil {
+		t.Error("expected an error for an invalid private key")
+	}
+}
+
+func TestSendTransactionsSpreadsOverSenders(t *testing.T) {
+	server, cfg := startDevChain(t, mockrpc.Profile{})
+	senders, err := DeriveSenders(cfg.PrivateKey, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Parallel = 2
+	cfg.Senders = senders
+
+	var scenarios []types.Scenario
+	for i := 1; i <= 5; i++ {
+		scenarios = append(scenarios, types.Scenario{ID: i, Desc: fmt.Sprintf("Scenario %d", i), Method: "eth_sendRawTransaction"})
+	}
+	// Sequence scenarios run alone after the others
+	scenarios[1].Sequence = func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *types.TxParams) ([]string, error) {
+		return nil, nil
+	}
+
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	output := captureStdout(t, func() { SendTransactions(context.Background(), client, cfg, scenarios) })
+
+	position := 0
+	for _, scenario := range scenarios {
+		next := strings.Index(output[position:], "Scenario: "+scenario.Desc+" ")
+		if next < 0 {
+			t.Fatalf("%s is missing or out of order:\n%s", scenario.Desc, output)
+		}
+		position += next
+	}
+
+	// Scenario i is sent from sender i % 2
+	var order []int
+	for _, req := range server.Requests() {
+		if req.Method != "eth_sendRawTransaction" {
+			continue
+		}
+		var id int
+		var params []hexutil.Bytes
+		if err := json.Unmarshal(req.Id, &id); err != nil || json.Unmarshal(req.Params, &params) != nil || len(params) != 1 {
+			t.Fatalf("unexpected request: %+v", req)
+		}
+		var tx gethTypes.Transaction
+		if err := tx.UnmarshalBinary(params[0]); err != nil {
+			t.Fatal(err)
+		}
+		sender, err := gethTypes.Sender(gethTypes.LatestSignerForChainID(tx.ChainId()), &tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := crypto.HexToECDSA(senders[(id-1)%2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := crypto.PubkeyToAddress(key.PublicKey); sender != want {
+			t.Errorf("scenario %d sent from %s, want %s", id, sender.Hex(), want.Hex())
+		}
+		order = append(order, id)
+	}
+	if len(order) != len(scenarios) || order[len(order)-1] != 2 {
+		t.Errorf("scenarios sent in order %v, want the sequence scenario 2 last", order)
+	}
+}
+
+func TestFundAndAdvanceSenders(t *testing.T) {
+	server, cfg := startDevChain(t, mockrpc.Profile{})
+	senders, err := DeriveSenders(cfg.PrivateKey, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := func(privateKey string) string {
+		t.Helper()
+		senderCfg, err := WithSender(cfg, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.ToLower(senderCfg.From)
+	}
+	funder := address(cfg.PrivateKey)
+	ether := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)) }
+
+	// The share is 12 / (2 * 3) = 2 ether: the first sender is empty, the second holds half its share
+	// and the third more than it, having already sent a transaction
+	balances := map[string]*big.Int{funder: ether(12), address(senders[0]): ether(0), address(senders[1]): ether(1), address(senders[2]): ether(3)}
+	nonces := map[string]string{funder: "0x5", address(senders[2]): "0x1"}
+	accountParam := func(req mockrpc.Request) string {
+		var params []string
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 {
+			return ""
+		}
+		return strings.ToLower(params[0])
+	}
+	server.Handle("eth_getBalance", func(req mockrpc.Request) (interface{}, *mockrpc.Error) {
+		return (*hexutil.Big)(balances[accountParam(req)]), nil
+	})
+	server.Handle("eth_getTransactionCount", func(req mockrpc.Request) (interface{}, *mockrpc.Error) {
+		if nonce, ok := nonces[accountParam(req)]; ok {
+			return nonce, nil
+		}
+		return "0x0", nil
+	})
+	server.Handle("eth_getTransactionReceipt", func(req mockrpc.Request) (interface{}, *mockrpc.Error) {
+		return &gethTypes.Receipt{
+			Status:      gethTypes.ReceiptStatusSuccessful,
+			TxHash:      common.HexToHash(accountParam(req)),
+			Logs:        []*gethTypes.Log{},
+			BlockNumber: big.NewInt(2),
+		}, nil
+	})
+
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := FundSenders(context.Background(), client, cfg, senders); err != nil {
+		t.Fatal(err)
+	}
+	if err := AdvanceSenders(context.Background(), client, cfg, senders); err != nil {
+		t.Fatal(err)
+	}
+
+	type sent struct {
+		from, to string
+		nonce    uint64
+		value    *big.Int
+		hash     string
+	}
+	var txs []sent
+	waited := make(map[string]bool)
+	for _, req := range server.Requests() {
+		switch req.Method {
+		case "eth_sendRawTransaction":
+			var params []hexutil.Bytes
+			if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+				t.Fatalf("unexpected request: %+v", req)
+			}
+			var tx gethTypes.Transaction
+			if err := tx.UnmarshalBinary(params[0]); err != nil {
+				t.Fatal(err)
+			}
+			from, err := gethTypes.Sender(gethTypes.LatestSignerForChainID(tx.ChainId()), &tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tx.Gas() != 21_000 {
+				t.Errorf("gas = %d, want a plain transfer", tx.Gas())
+			}
+			txs = append(txs, sent{strings.ToLower(from.Hex()), strings.ToLower(tx.To().Hex()), tx.Nonce(), tx.Value(), tx.Hash().Hex()})
+		case "eth_getTransactionReceipt":
+			waited[common.HexToHash(accountParam(req)).Hex()] = true
+		}
+	}
+
+	want := []sent{
+		{from: funder, to: address(senders[0]), nonce: 5, value: ether(2)},
+		{from: funder, to: address(senders[1]), nonce: 6, value: ether(1)},
+		{from: address(senders[0]), to: address(senders[0]), nonce: 0, value: ether(0)},
+		{from: address(senders[1]), to: address(senders[1]), nonce: 0, value: ether(0)},
+	}
+	if len(txs) != len(want) {
+		t.Fatalf("sent %d transactions, want %d: %+v", len(txs), len(want), txs)
+	}
+	for i, tx := range txs {
+		if tx.from != want[i].from || tx.to != want[i].to || tx.nonce != want[i].nonce || tx.value.Cmp(want[i].value) != 0 {
+			t.Errorf("transaction %d = %+v, want %+v", i, tx, want[i])
+		}
+	}
+	// The funder waits for its last top-up, which implies the first, and for every nonce advance
+	for _, i := range []int{1, 2, 3} {
+		if !waited[txs[i].hash] {
+			t.Errorf("transaction %d was not waited for", i)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"fmt"
 	"math/big"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/holiman/uint256"
 )
 
 func NewTxParamsFromDefaults(ctx context.Context, client *ethclient.Client, cfg config.Config, privateKey *ecdsa.PrivateKey, toAddress common.Address, input []byte) (*pkgTypes.TxParams, error) {
@@ -84,16 +87,108 @@ func BuildTransaction(params *pkgTypes.TxParams) *types.Transaction {
 			Data:      params.Data,
 		})
 	}
-	return types.NewTransaction(params.Nonce, *params.To, params.Value, params.Gas, params.GasPrice, params.Data)
+	// A nil To builds a contract creation, as it does for dynamic fee transactions
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    params.Nonce,
+		GasPrice: params.GasPrice,
+		Gas:      params.Gas,
+		To:       params.To,
+		Value:    params.Value,
+		Data:     params.Data,
+	})
+}
+
+// BuildBlobTransaction builds an EIP-4844 transaction carrying a single empty blob, with its sidecar attached
+// so that it can be submitted through eth_sendRawTransaction. Requires dynamic fee params.
+func BuildBlobTransaction(params *pkgTypes.TxParams, blobFeeCap *big.Int) (*types.Transaction, error) {
+	if !params.IsDynamic || params.GasTipCap == nil || params.GasFeeCap == nil {
+		return nil, fmt.Errorf("blob transactions require a network with EIP-1559 support")
+	}
+
+	if params.To == nil {
+		return nil, fmt.Errorf("blob transactions require a recipient")
+	}
+	gasTipCap, err := toUint256("gas tip cap", params.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+	gasFeeCap, err := toUint256("gas fee cap", params.GasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+	value, err := toUint256("value", params.Value)
+	if err != nil {
+		return nil, err
+	}
+	blobFee, err := toUint256("blob fee cap", blobFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
+	var blob kzg4844.Blob
+	commitment, err := kzg4844.BlobToCommitment(&blob)
+	if err != nil {
+		return nil, fmt.Errorf("error computing blob commitment: %w", err)
+	}
+	proof, err := kzg4844.ComputeBlobProof(&blob, commitment)
+	if err != nil {
+		return nil, fmt.Errorf("error computing blob proof: %w", err)
+	}
+
+	return types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(uint64(params.ChainID)),
+		Nonce:      params.Nonce,
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Gas:        params.Gas,
+		To:         *params.To,
+		Value:      value,
+		Data:       params.Data,
+		BlobFeeCap: blobFee,
+		BlobHashes: []common.Hash{kzg4844.CalcBlobHashV1(sha256.New(), &commitment)},
+		Sidecar: &types.BlobTxSidecar{
+			Blobs:       []kzg4844.Blob{blob},
+			Commitments: []kzg4844.Commitment{commitment},
+			Proofs:      []kzg4844.Proof{proof},
+		},
+	}), nil
+}
+
+// toUint256 converts a blob transaction field, which unlike the other transaction types cannot hold
+// negative or 256+ bit values. A nil value is zero.
+func toUint256(field string, value *big.Int) (*uint256.Int, error) {
+	if value == nil {
+		return new(uint256.Int), nil
+	}
+	converted, overflow := uint256.FromBig(value)
+	if overflow || value.Sign() < 0 {
+		return nil, fmt.Errorf("%s %s does not fit in 256 bits", field, value)
+	}
+	return converted, nil
 }
 
 func SignTransaction(tx *types.Transaction, params *pkgTypes.TxParams) (*types.Transaction, error) {
+	if params.PrivateKey == nil {
+		return nil, fmt.Errorf("no private key to sign with")
+	}
+	if params.ChainID < 0 {
+		return nil, fmt.Errorf("invalid chain id %d", params.ChainID)
+	}
+
 	var signer types.Signer
 	switch tx.Type() {
+	case types.BlobTxType: // 0x03 (EIP-4844)
+		signer = types.NewCancunSigner(big.NewInt(params.ChainID))
 	case types.DynamicFeeTxType: // 0x02 (EIP-1559)
 		signer = types.NewLondonSigner(big.NewInt(params.ChainID))
 	default: // 0x00 (Legacy)
-		signer = types.NewEIP155Signer(big.NewInt(params.ChainID))
+		// With chain id 0 the EIP-155 signer hashes for replay protection but emits an unprotected V,
+		// so receivers would recover another sender
+		if params.ChainID == 0 {
+			signer = types.HomesteadSigner{}
+		} else {
+			signer = types.NewEIP155Signer(big.NewInt(params.ChainID))
+		}
 	}
 
 	return types.SignTx(tx, signer, params.PrivateKey)
@@ -0,0 +1,123 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/eth-error-tests/pkg/types"
+)
+
+// checkSignedTransaction builds and signs params, then checks that whatever encodes round-trips and is accepted
+// by the mock server under the same hash and sender.
+func checkSignedTransaction(t *testing.T, url string, params *types.TxParams) {
+	t.Helper()
+	signed, err := SignTransaction(BuildTransaction(params), params)
+	if err != nil {
+		if params.ChainID >= 0 {
+			t.Fatalf("signing failed: %v", err)
+		}
+		return
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		// Negative values cannot be RLP encoded, SendTransaction reports them as encoding errors
+		return
+	}
+	var decoded gethTypes.Transaction
+	if err := decoded.UnmarshalBinary(raw); err != nil {
+		t.Fatalf("encoded transaction does not decode: %v", err)
+	}
+	if decoded.Hash() != signed.Hash() || decoded.Nonce() != params.Nonce || decoded.Gas() != params.Gas {
+		t.Fatalf("round-trip changed the transaction: %s", decoded.Hash().Hex())
+	}
+	sender, err := gethTypes.Sender(gethTypes.LatestSignerForChainID(signed.ChainId()), &decoded)
+	if err != nil || sender != params.FromAddress {
+		t.Fatalf("sender = %s (%v), want %s", sender.Hex(), err, params.FromAddress.Hex())
+	}
+
+	response, err := SendRawJSONRPCRequest(url, []types.JsonRpcRequest{
+		{JsonRpc: "2.0", Id: 1, Method: "eth_sendRawTransaction", Params: []interface{}{hexutil.Encode(raw)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes, err := BatchResponseToTxHashes(response)
+	if err != nil || len(hashes) != 1 || hashes[0] != signed.Hash().Hex() {
+		t.Fatalf("mock server returned %s, want hash %s", response, signed.Hash().Hex())
+	}
+}
+
+func bigFromBytes(b []byte, negative bool) *big.Int {
+	if len(b) == 0 {
+		return nil
+	}
+	value := new(big.Int).SetBytes(b)
+	if negative {
+		value.Neg(value)
+	}
+	return value
+}
+
+func FuzzBuildTransaction(f *testing.F) {
+	f.Add(uint64(0), uint64(21_000), []byte{0x3b, 0x9a, 0xca, 0x00}, []byte{0x01}, []byte{0x77, 0x35, 0x94, 0x00}, []byte{}, false, []byte{}, int64(testChainID), true, []byte{0xaa})
+	f.Add(uint64(1<<63), uint64(0), []byte{}, []byte{}, []byte{}, []byte{0xff}, true, []byte{0x60, 0x00}, int64(1), false, []byte{})
+	f.Add(uint64(5), uint64(1<<40), make([]byte, 40), []byte{}, []byte{0x01}, make([]byte, 33), false, make([]byte, 1024), int64(0), false, []byte{0xbb})
+	f.Add(uint64(7), uint64(30_000_000), []byte{0x01}, []byte{0x02}, []byte{0x01}, []byte{0x01}, false, []byte{}, int64(-1), true, []byte{})
+
+	_, cfg := startDevChain(f, mockrpc.Profile{})
+	key, err := crypto.HexToECDSA(cfg.PrivateKey)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, nonce, gas uint64, gasPrice, gasTipCap, gasFeeCap, value []byte, negativeValue bool, data []byte, chainID int64, dynamic bool, to []byte) {
+		params := &types.TxParams{
+			Nonce:       nonce,
+			Value:       bigFromBytes(value, negativeValue),
+			Data:        data,
+			Gas:         gas,
+			GasPrice:    bigFromBytes(gasPrice, false),
+			GasTipCap:   bigFromBytes(gasTipCap, false),
+			GasFeeCap:   bigFromBytes(gasFeeCap, false),
+			IsDynamic:   dynamic,
+			ChainID:     chainID,
+			PrivateKey:  key,
+			FromAddress: crypto.PubkeyToAddress(key.PublicKey),
+		}
+		if len(to) > 0 {
+			address := common.BytesToAddress(to)
+			params.To = &address
+		}
+
+		checkSignedTransaction(t, cfg.Url, params)
+	})
+}
+
+func TestSignTransactionRequiresPrivateKey(t *testing.T) {
+	params := &types.TxParams{Gas: 21_000, ChainID: testChainID}
+	if _, err := SignTransaction(BuildTransaction(params), params); err == nil {
+		t.Error("expected an error without a private key")
+	}
+}
+
+func TestBuildBlobTransactionRejectsOverflow(t *testing.T) {
+	to := common.HexToAddress("0xaa")
+	params := &types.TxParams{
+		To:        &to,
+		Value:     new(big.Int).Lsh(big.NewInt(1), 256),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(-1),
+		IsDynamic: true,
+		ChainID:   testChainID,
+	}
+	if _, err := BuildBlobTransaction(params, big.NewInt(1)); err == nil {
+		t.Error("expected an error for values outside uint256")
+	}
+}
@@ -2,9 +2,13 @@ package localnode
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	neturl "net/url"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
@@ -22,6 +26,7 @@ type NodeManager struct {
 	config      config.Config
 	containerID string
 	started     bool
+	jwtSecret   string
 }
 
 func NewNodeManager(cfg config.Config) *NodeManager {
@@ -50,16 +55,18 @@ func (nm *NodeManager) StartAndFund() (string, error) {
 	var cmd *exec.Cmd
 	switch nm.config.LocalNodeType {
 	case "geth":
+		// --dev drives the chain with geth's simulated beacon and never starts the authenticated Engine API
+		// server, so no JWT secret is mounted and the Engine API scenarios are skipped
 		cmd = exec.Command("docker", "run", "-d",
 			"--name", containerName,
-			"-p", "8545:8545",
+			"-p", portMapping(nm.config.Url, "8545"),
 			"ethereum/client-go:latest",
 			"--dev",
 			"--dev.period", "1",
 			"--http",
 			"--http.addr", "0.0.0.0",
 			"--http.port", "8545",
-			"--http.api", "eth,net,web3,debug,personal",
+			"--http.api", "eth,net,web3,debug,personal,txpool",
 			"--http.corsdomain", "*",
 			"--allow-insecure-unlock",
 			"--verbosity", "3",
@@ -67,6 +74,10 @@ func (nm *NodeManager) StartAndFund() (string, error) {
 			"--password", "/dev/null", // Empty password for dev mode
 		)
 	case "besu":
+		jwtPath, err := nm.writeJWTSecret()
+		if err != nil {
+			return "", fmt.Errorf("failed to write jwt secret: %w", err)
+		}
 		// Get the absolute path to the genesis.json file
 		_, currentFile, _, _ := runtime.Caller(0)
 		pkgDir := filepath.Dir(currentFile)
@@ -74,7 +85,9 @@ func (nm *NodeManager) StartAndFund() (string, error) {
 		// https://besu.hyperledger.org/public-networks/reference/cli/options#min-priority-fee
 		cmd = exec.Command("docker", "run", "-d",
 			"--name", containerName,
-			"-p", "8545:8545",
+			"-p", portMapping(nm.config.Url, "8545"),
+			"-p", portMapping(nm.config.EngineUrl, "8551"),
+			"-v", fmt.Sprintf("%s:/jwt.hex:ro", jwtPath),
 			// https://github.com/hyperledger/besu/blob/750580dcca349d22d024cc14a8171b2fa74b505a/config/src/main/resources/dev.json
 			"-v", fmt.Sprintf("%s:/genesis.json:ro", genesisPath), // Mount genesis file as read-only
 			"hyperledger/besu:latest",
@@ -84,7 +97,7 @@ func (nm *NodeManager) StartAndFund() (string, error) {
 			"--rpc-http-enabled",
 			"--rpc-http-host=0.0.0.0",
 			"--rpc-http-port=8545",
-			"--rpc-http-api=ETH,NET,WEB3,DEBUG",
+			"--rpc-http-api=ETH,NET,WEB3,DEBUG,TRACE,TXPOOL",
 			"--rpc-http-cors-origins=*",
 			"--host-allowlist=*",
 			"--rpc-gas-cap=167700000",
@@ -92,6 +105,10 @@ func (nm *NodeManager) StartAndFund() (string, error) {
 			"--min-priority-fee=10",
 			"--rpc-tx-feecap=100000000000",
 			"--logging=DEBUG",
+			"--engine-rpc-enabled",
+			"--engine-rpc-port=8551",
+			"--engine-host-allowlist=*",
+			"--engine-jwt-secret=/jwt.hex",
 		)
 	default:
 		return "", fmt.Errorf("unsupported client: %s", nm.config.LocalNodeType)
@@ -133,6 +150,36 @@ func (nm *NodeManager) StartAndFund() (string, error) {
 	return devAccount, nil
 }
 
+// writeJWTSecret generates a fresh Engine API secret and writes it to a file that is mounted into the container.
+func (nm *NodeManager) writeJWTSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	nm.jwtSecret = hex.EncodeToString(secret)
+
+	jwtPath := filepath.Join(os.TempDir(), fmt.Sprintf("eip-test-%s-jwt.hex", nm.config.LocalNodeType))
+	if err := os.WriteFile(jwtPath, []byte(nm.jwtSecret), 0o644); err != nil {
+		return "", err
+	}
+	return jwtPath, nil
+}
+
+// portMapping publishes containerPort on the port of url, so several nodes can run side by side
+// when their configs use different ports.
+func portMapping(url, containerPort string) string {
+	hostPort := containerPort
+	if parsed, err := neturl.Parse(url); err == nil && parsed.Port() != "" {
+		hostPort = parsed.Port()
+	}
+	return hostPort + ":" + containerPort
+}
+
+// JWTSecret returns the hex encoded Engine API secret of the running node, empty if it serves no Engine API.
+func (nm *NodeManager) JWTSecret() string {
+	return nm.jwtSecret
+}
+
 func (nm *NodeManager) waitForNode() error {
 	for i := 0; i < 60; i++ {
 		client, err := ethclient.Dial(nm.config.Url)
@@ -280,6 +327,9 @@ func (nm *NodeManager) fundAccountUnlocked(fromAddr, toAddr string) (string, err
 	if err != nil {
 		return "", fmt.Errorf("failed to parse transaction hash: %w", err)
 	}
+	if len(txhashes) == 0 {
+		return "", fmt.Errorf("transaction was not accepted: %s", response)
+	}
 
 	return txhashes[0], nil
 }
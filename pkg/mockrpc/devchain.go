@@ -0,0 +1,78 @@
+package mockrpc
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// HandleDevChain registers the happy-path handlers ethclient needs to build and submit a transaction
+// (chain id, nonce, gas estimation, fee market and head lookups), so profiles only have to declare the
+// error behavior under test. Raw transactions are decoded and acknowledged with their hash but never mined.
+func (s *Server) HandleDevChain(chainID int64) {
+	head := &types.Header{
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(0),
+		GasLimit:   30_000_000,
+		Time:       1,
+		BaseFee:    big.NewInt(1_000_000_000),
+	}
+
+	s.Handle("eth_chainId", constant(hexutil.EncodeBig(big.NewInt(chainID))))
+	s.Handle("net_version", constant(big.NewInt(chainID).String()))
+	s.Handle("eth_blockNumber", constant(hexutil.EncodeBig(head.Number)))
+	s.Handle("eth_getTransactionCount", constant("0x0"))
+	s.Handle("eth_getBalance", constant("0xde0b6b3a7640000"))
+	s.Handle("eth_estimateGas", constant("0x5208"))
+	s.Handle("eth_gasPrice", constant("0x3b9aca00"))
+	s.Handle("eth_maxPriorityFeePerGas", constant("0x3b9aca00"))
+	s.Handle("eth_getTransactionByHash", constant(nil))
+	s.Handle("eth_getTransactionReceipt", constant(nil))
+	s.Handle("eth_getBlockByNumber", func(req Request) (interface{}, *Error) {
+		return blockJSON(head)
+	})
+	s.Handle("eth_sendRawTransaction", func(req Request) (interface{}, *Error) {
+		var params []hexutil.Bytes
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			return nil, &Error{Code: -32602, Message: "invalid argument 0: expected raw transaction"}
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(params[0]); err != nil {
+			return nil, &Error{Code: -32000, Message: "rlp: " + err.Error()}
+		}
+		return tx.Hash(), nil
+	})
+}
+
+func constant(result interface{}) Handler {
+	return func(req Request) (interface{}, *Error) {
+		return result, nil
+	}
+}
+
+// blockJSON renders the header as an RPC block object without transactions.
+func blockJSON(head *types.Header) (interface{}, *Error) {
+	encoded, err := json.Marshal(head)
+	if err != nil {
+		return nil, &Error{Code: -32603, Message: err.Error()}
+	}
+
+	var block map[string]interface{}
+	if err := json.Unmarshal(encoded, &block); err != nil {
+		return nil, &Error{Code: -32603, Message: err.Error()}
+	}
+	// Like clients, omit the fields of forks the header predates instead of returning null
+	for field, value := range block {
+		if value == nil {
+			delete(block, field)
+		}
+	}
+	block["transactions"] = []common.Hash{}
+	block["uncles"] = []common.Hash{}
+	block["size"] = hexutil.Uint64(types.NewBlockWithHeader(head).Size())
+
+	return block, nil
+}
@@ -0,0 +1,194 @@
+// Package mockrpc provides an in-process JSON-RPC server that emulates the error behavior of an
+// Ethereum client from a declarative Profile, so the suite can be developed and tested offline.
+package mockrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// Request is a single JSON-RPC call as received by the server
+type Request struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Handler produces the result or error for a single JSON-RPC call.
+type Handler func(req Request) (interface{}, *Error)
+
+// Server is an HTTP JSON-RPC server. A matching profile rule takes precedence over a handler registered
+// with Handle, so profiles can declare error behavior on top of the default handlers; calls matched by
+// neither get the profile's method-not-found error.
+type Server struct {
+	profile  Profile
+	handlers map[string]Handler
+
+	mu       sync.Mutex
+	requests []Request
+
+	listener   net.Listener
+	httpServer *http.Server
+}
+
+func NewServer(profile Profile) *Server {
+	return &Server{
+		profile:  profile,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers a handler for method, replacing any previous handler.
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Start listens on addr (e.g. "127.0.0.1:0") and returns the server URL.
+func (s *Server) Start(addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	s.listener = listener
+	s.httpServer = &http.Server{Handler: s}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Mock RPC server error: %v\n", err)
+		}
+	}()
+
+	return "http://" + listener.Addr().String(), nil
+}
+
+func (s *Server) Close() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Close()
+}
+
+// Requests returns every call received so far, with batch members flattened in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.handleBody(body)); err != nil {
+		fmt.Printf("Mock RPC server error: %v\n", err)
+	}
+}
+
+func (s *Server) handleBody(body []byte) interface{} {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '[' && trimmed[0] != '{') {
+		return errorResponse(nil, &Error{Code: -32700, Message: "parse error"})
+	}
+
+	if trimmed[0] == '{' {
+		var req Request
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			return errorResponse(nil, &Error{Code: -32700, Message: "parse error"})
+		}
+		return s.call(req)
+	}
+
+	var batch []Request
+	if err := json.Unmarshal(trimmed, &batch); err != nil {
+		return errorResponse(nil, &Error{Code: -32700, Message: "parse error"})
+	}
+	return s.handleBatch(batch)
+}
+
+func (s *Server) handleBatch(batch []Request) interface{} {
+	switch {
+	case len(batch) == 0:
+		return errorResponse(nil, &Error{Code: -32600, Message: "empty batch"})
+	case s.profile.Batch == BatchUnsupported:
+		return errorResponse(nil, s.profile.batchError("batch requests are not supported"))
+	case s.profile.MaxBatchSize > 0 && len(batch) > s.profile.MaxBatchSize:
+		return errorResponse(nil, s.profile.batchError("batch too large"))
+	}
+
+	if s.profile.Batch == BatchUnwrapSingle && len(batch) == 1 {
+		return s.call(batch[0])
+	}
+
+	responses := make([]interface{}, 0, len(batch))
+	for _, req := range batch {
+		responses = append(responses, s.call(req))
+	}
+	return responses
+}
+
+func (s *Server) call(req Request) interface{} {
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	handler, ok := s.handlers[req.Method]
+	s.mu.Unlock()
+
+	if rule, ok := s.profile.match(req); ok {
+		if rule.Error != nil {
+			return errorResponse(req.Id, rule.Error)
+		}
+		return resultResponse(req.Id, rule.Result)
+	}
+
+	if ok {
+		result, rpcErr := handler(req)
+		if rpcErr != nil {
+			return errorResponse(req.Id, rpcErr)
+		}
+		return resultResponse(req.Id, result)
+	}
+
+	return errorResponse(req.Id, s.profile.methodNotFound(req.Method))
+}
+
+func resultResponse(id json.RawMessage, result interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      rawID(id),
+		"result":  result,
+	}
+}
+
+func errorResponse(id json.RawMessage, rpcErr *Error) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      rawID(id),
+		"error":   rpcErr,
+	}
+}
+
+func rawID(id json.RawMessage) json.RawMessage {
+	if len(id) == 0 {
+		return json.RawMessage("null")
+	}
+	return id
+}
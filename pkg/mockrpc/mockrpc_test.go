@@ -0,0 +1,168 @@
+package mockrpc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func startServer(t *testing.T, profile Profile) (*Server, string) {
+	t.Helper()
+	server := NewServer(profile)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start server: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server, url
+}
+
+func post(t *testing.T, url, body string) string {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func TestProfileRules(t *testing.T) {
+	profile := Profile{
+		Methods: map[string][]Rule{
+			"eth_call": {
+				{Match: `"unsupported"`, Error: &Error{Code: -32602, Message: "invalid block tag"}},
+				{Result: json.RawMessage(`"0x"`)},
+			},
+		},
+	}
+	_, url := startServer(t, profile)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "matching rule error",
+			body: `{"jsonrpc":"2.0","id":1,"method":"eth_call","params":[{},"unsupported"]}`,
+			want: `{"error":{"code":-32602,"message":"invalid block tag"},"id":1,"jsonrpc":"2.0"}`,
+		},
+		{
+			name: "fallback rule result",
+			body: `{"jsonrpc":"2.0","id":2,"method":"eth_call","params":[{},"latest"]}`,
+			want: `{"id":2,"jsonrpc":"2.0","result":"0x"}`,
+		},
+		{
+			name: "method not found",
+			body: `{"jsonrpc":"2.0","id":"a","method":"eth_unknown","params":[]}`,
+			want: `{"error":{"code":-32601,"message":"the method eth_unknown does not exist/is not available"},"id":"a","jsonrpc":"2.0"}`,
+		},
+		{
+			name: "parse error",
+			body: `not json`,
+			want: `{"error":{"code":-32700,"message":"parse error"},"id":null,"jsonrpc":"2.0"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := post(t, url, tt.body); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchModes(t *testing.T) {
+	call := `{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`
+	result := `{"id":1,"jsonrpc":"2.0","result":"0x1"}`
+
+	tests := []struct {
+		name    string
+		profile Profile
+		body    string
+		want    string
+	}{
+		{
+			name: "array",
+			body: "[" + call + "," + call + "]",
+			want: "[" + result + "," + result + "]",
+		},
+		{
+			name:    "unsupported",
+			profile: Profile{Batch: BatchUnsupported},
+			body:    "[" + call + "]",
+			want:    `{"error":{"code":-32600,"message":"batch requests are not supported"},"id":null,"jsonrpc":"2.0"}`,
+		},
+		{
+			name:    "too large",
+			profile: Profile{MaxBatchSize: 1},
+			body:    "[" + call + "," + call + "]",
+			want:    `{"error":{"code":-32600,"message":"batch too large"},"id":null,"jsonrpc":"2.0"}`,
+		},
+		{
+			name:    "unwrap single",
+			profile: Profile{Batch: BatchUnwrapSingle},
+			body:    "[" + call + "]",
+			want:    result,
+		},
+		{
+			name: "empty",
+			body: "[]",
+			want: `{"error":{"code":-32600,"message":"empty batch"},"id":null,"jsonrpc":"2.0"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, url := startServer(t, tt.profile)
+			server.Handle("eth_chainId", constant("0x1"))
+			if got := post(t, url, tt.body); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestsAreRecorded(t *testing.T) {
+	server, url := startServer(t, Profile{})
+	post(t, url, `[{"jsonrpc":"2.0","id":1,"method":"a","params":[]},{"jsonrpc":"2.0","id":2,"method":"b","params":[]}]`)
+
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].Method != "a" || requests[1].Method != "b" {
+		t.Fatalf("unexpected recorded requests: %+v", requests)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"name":"strict","batch":"unsupported","methods":{"eth_call":[{"match":"latest","result":"0x"}]}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	profile, err := LoadProfile(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Name != "strict" || profile.Batch != BatchUnsupported || len(profile.Methods["eth_call"]) != 1 {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"methods":{"eth_call":[{"match":"("}]}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProfile(invalid); err == nil {
+		t.Error("expected an error for an invalid match pattern")
+	}
+}
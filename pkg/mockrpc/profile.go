@@ -0,0 +1,95 @@
+package mockrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// BatchMode controls how a profile answers batch requests
+type BatchMode string
+
+const (
+	BatchArray        BatchMode = ""             // Answer with an array, one response per call (default)
+	BatchUnsupported  BatchMode = "unsupported"  // Answer every batch with a single error object
+	BatchUnwrapSingle BatchMode = "unwrapSingle" // Answer single-element batches with a bare object
+)
+
+// Profile declares how an emulated client responds. It can be loaded from a JSON file, e.g.
+//
+//	{
+//	  "name": "strict-client",
+//	  "maxBatchSize": 2,
+//	  "methods": {
+//	    "eth_call": [
+//	      {"match": "\"unsupported\"", "error": {"code": -32602, "message": "invalid block tag"}},
+//	      {"result": "0x"}
+//	    ]
+//	  }
+//	}
+type Profile struct {
+	Name           string            `json:"name"`
+	Batch          BatchMode         `json:"batch"`
+	MaxBatchSize   int               `json:"maxBatchSize"`   // 0 means unlimited
+	BatchError     *Error            `json:"batchError"`     // Returned for rejected batches, defaults to -32600
+	MethodNotFound *Error            `json:"methodNotFound"` // Defaults to -32601 with geth's message
+	Methods        map[string][]Rule `json:"methods"`
+}
+
+// Rule answers calls whose raw params match Match. The first matching rule of a method wins;
+// an empty Match matches every call.
+type Rule struct {
+	Match  string          `json:"match"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// LoadProfile reads a JSON profile from path.
+func LoadProfile(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return Profile{}, fmt.Errorf("failed to parse profile %s: %w", path, err)
+	}
+
+	for method, rules := range profile.Methods {
+		for _, rule := range rules {
+			if _, err := regexp.Compile(rule.Match); err != nil {
+				return Profile{}, fmt.Errorf("invalid match for %s: %w", method, err)
+			}
+		}
+	}
+
+	return profile, nil
+}
+
+func (p Profile) match(req Request) (Rule, bool) {
+	for _, rule := range p.Methods[req.Method] {
+		if rule.Match == "" {
+			return rule, true
+		}
+		if matched, err := regexp.Match(rule.Match, req.Params); err == nil && matched {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func (p Profile) methodNotFound(method string) *Error {
+	if p.MethodNotFound != nil {
+		return p.MethodNotFound
+	}
+	return &Error{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", method)}
+}
+
+func (p Profile) batchError(message string) *Error {
+	if p.BatchError != nil {
+		return p.BatchError
+	}
+	return &Error{Code: -32600, Message: message}
+}
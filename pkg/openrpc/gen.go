@@ -0,0 +1,42 @@
+//go:build ignore
+
+// gen writes the embedded execution-apis.json from a refs-openrpc.json build of ethereum/execution-apis
+// (built with `npm run build` in a checkout of the pinned tag or commit):
+//
+//	EXECUTION_APIS_SPEC=/path/to/refs-openrpc.json EXECUTION_APIS_REF=<tag or commit> go generate ./pkg/openrpc
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/eth-error-tests/pkg/openrpc"
+)
+
+func main() {
+	in := flag.String("in", "", "refs-openrpc.json build of execution-apis")
+	ref := flag.String("ref", "", "execution-apis tag or commit the build was made from")
+	out := flag.String("out", "execution-apis.json", "Output file")
+	flag.Parse()
+
+	if *in == "" || *ref == "" {
+		fmt.Fprintln(os.Stderr, "usage: go run gen.go -in refs-openrpc.json -ref <tag or commit> [-out execution-apis.json]")
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	subset, err := openrpc.Subset(data, "ethereum/execution-apis@"+*ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *in, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, subset, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,136 @@
+// Package openrpc validates successful JSON-RPC results against an OpenRPC specification, by default an
+// embedded, hand-written approximation of the Ethereum execution-apis result schemas.
+package openrpc
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// execution-apis.json is hand-written in the layout gen.go produces. Running gen.go on a pinned
+// refs-openrpc.json build replaces it (see Subset) and records the upstream tag or commit in info.x-source.
+//
+//go:generate go run gen.go -in $EXECUTION_APIS_SPEC -ref $EXECUTION_APIS_REF
+//go:embed execution-apis.json
+var executionApis []byte
+
+// Spec is an OpenRPC document with its refs expected under #/components/schemas.
+type Spec struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Methods    []Method `json:"methods"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+
+	methods   map[string]*Method
+	validator *validator
+}
+
+type Method struct {
+	Name   string             `json:"name"`
+	Result *ContentDescriptor `json:"result"`
+}
+
+type ContentDescriptor struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+// Default returns the embedded result schemas.
+func Default() *Spec {
+	spec, err := Parse(executionApis)
+	if err != nil {
+		panic(fmt.Sprintf("embedded result schemas: %v", err))
+	}
+	return spec
+}
+
+// Load reads an OpenRPC document, e.g. a newer refs-openrpc.json build of execution-apis.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes an OpenRPC document and checks that every $ref resolves.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+
+	spec.validator = &validator{components: spec.Components.Schemas}
+	spec.methods = make(map[string]*Method, len(spec.Methods))
+	for i := range spec.Methods {
+		method := &spec.Methods[i]
+		if method.Result == nil || method.Result.Schema == nil {
+			return nil, fmt.Errorf("method %s has no result schema", method.Name)
+		}
+		if err := spec.checkRefs(method.Result.Schema, make(map[*Schema]bool)); err != nil {
+			return nil, fmt.Errorf("method %s: %w", method.Name, err)
+		}
+		spec.methods[method.Name] = method
+	}
+	return &spec, nil
+}
+
+func (s *Spec) checkRefs(schema *Schema, seen map[*Schema]bool) error {
+	if schema == nil || seen[schema] {
+		return nil
+	}
+	seen[schema] = true
+
+	resolved, err := s.validator.resolve(schema)
+	if err != nil {
+		return err
+	}
+	children := append([]*Schema{resolved, resolved.Items}, resolved.OneOf...)
+	children = append(children, resolved.AnyOf...)
+	children = append(children, resolved.AllOf...)
+	for _, property := range resolved.Properties {
+		children = append(children, property)
+	}
+	for _, child := range children[1:] {
+		if err := s.checkRefs(child, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasMethod reports whether the spec defines method.
+func (s *Spec) HasMethod(method string) bool {
+	_, ok := s.methods[method]
+	return ok
+}
+
+// ValidateResult returns the violations of a decoded result against the method's result schema.
+// Methods the spec does not define have none.
+func (s *Spec) ValidateResult(method string, result interface{}) []string {
+	m, ok := s.methods[method]
+	if !ok {
+		return nil
+	}
+	return s.validator.validate(m.Result.Schema, result, "result")
+}
+
+// Summary is a one-line description of the violations, keeping the first few.
+func Summary(violations []string) string {
+	const shown = 3
+	if len(violations) <= shown {
+		return strings.Join(violations, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(violations[:shown], "; "), len(violations)-shown)
+}
@@ -0,0 +1,149 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestValidateResult(t *testing.T) {
+	spec := Default()
+
+	tests := []struct {
+		method string
+		result string
+		want   string // Substring of the first violation, empty for valid results
+	}{
+		{"eth_estimateGas", `"0x5839"`, ""},
+		{"eth_estimateGas", `"0x05839"`, "does not match hex encoded unsigned integer"},
+		{"eth_estimateGas", `21000`, "expected string"},
+		{"eth_getBalance", `"0x0"`, ""},
+		{"eth_call", `"0x"`, ""},
+		{"eth_call", `"0xABCD"`, "does not match hex encoded bytes"},
+		{"eth_coinbase", `"0x8943545177806ED17B9F23F0a21ee5948eCaa776"`, ""},
+		{"eth_sendRawTransaction", `"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"`, ""},
+		{"eth_sendRawTransaction", `"0x5c50"`, "does not match 32 byte hex value"},
+		{"eth_getTransactionReceipt", `null`, ""},
+		{"eth_getBlockByNumber", `{"number":"0x1"}`, `result: missing required field "hash"`},
+		{"eth_getBlockByNumber", `"0x1"`, "matches none of [notFound, Block]"},
+		{"eth_syncing", `false`, ""},
+		{"eth_syncing", `{"startingBlock":"0x0","currentBlock":"0x1","highestBlock":"0x2"}`, ""},
+		{"eth_feeHistory", `{"oldestBlock":"0x1","baseFeePerGas":["0x3b9aca00","0x342770c0"],"gasUsedRatio":[0.5]}`, ""},
+		{"eth_feeHistory", `{"oldestBlock":"0x1","baseFeePerGas":["0x1"],"gasUsedRatio":[1.5]}`, "result.gasUsedRatio[0]: 1.5 is above 1"},
+		{"eth_getLogs", `[]`, ""},
+		{"eth_getLogs", `[{"address":"0x00000000000000000000000000000000000000aa","topics":[],"data":"0x","blockNumber":"0x1",` +
+			`"transactionHash":"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060","transactionIndex":"0x0",` +
+			`"blockHash":"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060","logIndex":"0x0","removed":false}]`, ""},
+		{"eth_getTransactionByHash", `{"type":"0x0","nonce":"0x1","gasPrice":"0x1","gas":"0x5208","to":null,"value":"0x0","input":"0x",` +
+			`"v":"0x1b","r":"0x1","s":"0x1","hash":"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",` +
+			`"blockHash":null,"blockNumber":null,"transactionIndex":null,"from":"0x00000000000000000000000000000000000000aa"}`,
+			"result.blockHash: expected string, got null"},
+		{"eth_unknownMethod", `"anything"`, ""},
+	}
+
+	for _, tt := range tests {
+		violations := spec.ValidateResult(tt.method, decode(t, tt.result))
+		switch {
+		case tt.want == "" && len(violations) > 0:
+			t.Errorf("%s %s: unexpected violations %v", tt.method, tt.result, violations)
+		case tt.want != "" && (len(violations) == 0 || !strings.Contains(violations[0], tt.want)):
+			t.Errorf("%s %s: violations %v, want %q", tt.method, tt.result, violations, tt.want)
+		}
+	}
+}
+
+func TestParseRejectsUnresolvableRefs(t *testing.T) {
+	doc := `{"methods":[{"name":"eth_x","result":{"name":"x","schema":{"$ref":"#/components/schemas/missing"}}}]}`
+	if _, err := Parse([]byte(doc)); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("expected an unresolvable ref error, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.json")
+	doc := `{"methods":[{"name":"eth_x","result":{"name":"x","schema":{"type":"boolean"}}}]}`
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !spec.HasMethod("eth_x") || spec.HasMethod("eth_call") {
+		t.Error("loaded spec should only define eth_x")
+	}
+	if violations := spec.ValidateResult("eth_x", "true"); len(violations) != 1 {
+		t.Errorf("violations = %v, want one", violations)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	if got := Summary([]string{"a", "b", "c", "d", "e"}); got != "a; b; c; and 2 more" {
+		t.Errorf("Summary = %q", got)
+	}
+}
+
+func TestSubset(t *testing.T) {
+	build := `{
+		"openrpc": "1.2.4",
+		"info": {"title": "Ethereum JSON-RPC Specification", "version": "1.2.3"},
+		"methods": [
+			{"name": "eth_getBlockByNumber", "params": [{"name": "Block"}], "result": {"name": "Block", "schema": {"$ref": "#/components/schemas/Block"}}},
+			{"name": "eth_chainId", "result": {"name": "Chain ID", "schema": {"$ref": "#/components/schemas/uint"}}},
+			{"name": "debug_getRawBlock", "result": {"name": "Block RLP", "schema": {"$ref": "#/components/schemas/bytes"}}}
+		],
+		"components": {"schemas": {
+			"Block": {"type": "object", "properties": {"number": {"$ref": "#/components/schemas/uint"}}},
+			"uint": {"type": "string", "pattern": "^0x(0|[1-9a-f][0-9a-f]*)$"},
+			"bytes": {"type": "string"},
+			"unused": {"type": "string"}
+		}}
+	}`
+
+	out, err := Subset([]byte(build), "ethereum/execution-apis@v1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var subset struct {
+		Info    map[string]interface{} `json:"info"`
+		Methods []struct {
+			Name   string          `json:"name"`
+			Params json.RawMessage `json:"params"`
+		} `json:"methods"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(out, &subset); err != nil {
+		t.Fatal(err)
+	}
+
+	if subset.Info["x-source"] != "ethereum/execution-apis@v1.2.3" || subset.Info["version"] != "1.2.3" {
+		t.Errorf("info = %v, want upstream's with the source recorded", subset.Info)
+	}
+	if len(subset.Methods) != 2 || subset.Methods[0].Name != "eth_chainId" || subset.Methods[1].Name != "eth_getBlockByNumber" || subset.Methods[1].Params != nil {
+		t.Errorf("methods = %+v, want the eth_ methods without params", subset.Methods)
+	}
+	if len(subset.Components.Schemas) != 2 || subset.Components.Schemas["Block"] == nil || subset.Components.Schemas["uint"] == nil {
+		t.Errorf("schemas = %v, want those reached from the eth_ results", subset.Components.Schemas)
+	}
+
+	if _, err := Subset([]byte(build), ""); err == nil {
+		t.Error("expected an error without an upstream source")
+	}
+	broken := strings.Replace(build, `"$ref": "#/components/schemas/uint"}}}`, `"$ref": "#/components/schemas/missing"}}}`, 1)
+	if _, err := Subset([]byte(broken), "ethereum/execution-apis@v1.2.3"); err == nil {
+		t.Error("expected an error for an unresolvable ref")
+	}
+}
@@ -0,0 +1,249 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Schema is the subset of JSON Schema used by the execution-apis specification.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Title                string             `json:"title"`
+	Type                 string             `json:"type"`
+	Pattern              string             `json:"pattern"`
+	Enum                 []interface{}      `json:"enum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	OneOf                []*Schema          `json:"oneOf"`
+	AnyOf                []*Schema          `json:"anyOf"`
+	AllOf                []*Schema          `json:"allOf"`
+}
+
+var (
+	patternsMu sync.Mutex
+	patterns   = make(map[string]*regexp.Regexp)
+)
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	if re, ok := patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns[pattern] = re
+	return re, nil
+}
+
+// validator checks decoded JSON values against schemas, resolving refs to #/components/schemas.
+type validator struct {
+	components map[string]*Schema
+}
+
+func (v *validator) resolve(schema *Schema) (*Schema, error) {
+	for depth := 0; schema.Ref != ""; depth++ {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := v.components[name]
+		if !ok || depth > 32 {
+			return nil, fmt.Errorf("unresolvable $ref %q", schema.Ref)
+		}
+		schema = resolved
+	}
+	return schema, nil
+}
+
+// validate returns the violations of value at path, one per message.
+func (v *validator) validate(schema *Schema, value interface{}, path string) []string {
+	schema, err := v.resolve(schema)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", path, err)}
+	}
+
+	var violations []string
+	for _, sub := range schema.AllOf {
+		violations = append(violations, v.validate(sub, value, path)...)
+	}
+	// execution-apis' oneOf alternatives overlap (e.g. the signed transaction types), so like anyOf
+	// one match is enough
+	for _, alternatives := range [][]*Schema{schema.OneOf, schema.AnyOf} {
+		if len(alternatives) > 0 {
+			violations = append(violations, v.validateAlternatives(alternatives, value, path)...)
+		}
+	}
+
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		return append(violations, fmt.Sprintf("%s: expected %s, got %s", path, schema.Type, describe(value)))
+	}
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		violations = append(violations, fmt.Sprintf("%s: %s is not one of %v", path, describe(value), schema.Enum))
+	}
+
+	switch value := value.(type) {
+	case string:
+		if schema.Pattern != "" {
+			re, err := compilePattern(schema.Pattern)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("%s: invalid pattern %q in spec", path, schema.Pattern))
+			} else if !re.MatchString(value) {
+				violations = append(violations, fmt.Sprintf("%s: %s does not match %s", path, describe(value), patternName(schema)))
+			}
+		}
+	case float64:
+		if schema.Minimum != nil && value < *schema.Minimum {
+			violations = append(violations, fmt.Sprintf("%s: %v is below %v", path, value, *schema.Minimum))
+		}
+		if schema.Maximum != nil && value > *schema.Maximum {
+			violations = append(violations, fmt.Sprintf("%s: %v is above %v", path, value, *schema.Maximum))
+		}
+	case []interface{}:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			violations = append(violations, fmt.Sprintf("%s: %d items, expected at least %d", path, len(value), *schema.MinItems))
+		}
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			violations = append(violations, fmt.Sprintf("%s: %d items, expected at most %d", path, len(value), *schema.MaxItems))
+		}
+		if schema.Items != nil {
+			for i, item := range value {
+				violations = append(violations, v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case map[string]interface{}:
+		for _, field := range schema.Required {
+			if _, ok := value[field]; !ok {
+				violations = append(violations, fmt.Sprintf("%s: missing required field %q", path, field))
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := schema.Properties[key]; ok {
+				violations = append(violations, v.validate(property, value[key], path+"."+key)...)
+			} else if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				violations = append(violations, fmt.Sprintf("%s: unexpected field %q", path, key))
+			}
+		}
+	}
+
+	return violations
+}
+
+// validateAlternatives returns nothing if value matches one of the alternatives. Otherwise, when a single
+// alternative has value's type (e.g. Block rather than notFound for an object), its violations are the
+// most useful explanation.
+func (v *validator) validateAlternatives(alternatives []*Schema, value interface{}, path string) []string {
+	var candidates [][]string
+	for _, alternative := range alternatives {
+		violations := v.validate(alternative, value, path)
+		if len(violations) == 0 {
+			return nil
+		}
+		if resolved, err := v.resolve(alternative); err == nil && (resolved.Type == "" || hasType(value, resolved.Type)) {
+			candidates = append(candidates, violations)
+		}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return []string{fmt.Sprintf("%s: %s matches none of %s", path, describe(value), titles(alternatives))}
+}
+
+func hasType(value interface{}, typ string) bool {
+	switch typ {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		return true
+	}
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		data, _ := json.Marshal(value)
+		return truncate(string(data), 80)
+	}
+}
+
+func schemaName(schema *Schema, fallback string) string {
+	if schema.Title != "" {
+		return schema.Title
+	}
+	return fallback
+}
+
+func patternName(schema *Schema) string {
+	if schema.Title != "" {
+		return fmt.Sprintf("%s (%s)", schema.Title, schema.Pattern)
+	}
+	return schema.Pattern
+}
+
+// titles names alternatives by their ref, falling back to their title or type.
+func titles(schemas []*Schema) string {
+	names := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		if name == "" {
+			name = schemaName(schema, schema.Type)
+		}
+		names = append(names, name)
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
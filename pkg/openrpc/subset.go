@@ -0,0 +1,105 @@
+package openrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Subset reduces a refs-openrpc.json build of execution-apis to what result validation needs: the eth_ methods
+// with their result descriptors and the component schemas those reach. Upstream's info is kept and source,
+// the upstream tag or commit the build was made from, is recorded as info.x-source.
+func Subset(data []byte, source string) ([]byte, error) {
+	if source == "" {
+		return nil, fmt.Errorf("missing upstream source")
+	}
+
+	var doc struct {
+		OpenRPC    string                   `json:"openrpc"`
+		Info       map[string]interface{}   `json:"info"`
+		Methods    []map[string]interface{} `json:"methods"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Info == nil {
+		return nil, fmt.Errorf("missing info")
+	}
+
+	var methods []map[string]interface{}
+	reached := make(map[string]bool)
+	for _, method := range doc.Methods {
+		name, _ := method["name"].(string)
+		if !strings.HasPrefix(name, "eth_") {
+			continue
+		}
+		result, ok := method["result"]
+		if !ok {
+			return nil, fmt.Errorf("method %s has no result", name)
+		}
+		if err := collectRefs(result, doc.Components.Schemas, reached); err != nil {
+			return nil, fmt.Errorf("method %s: %w", name, err)
+		}
+		methods = append(methods, map[string]interface{}{"name": name, "result": result})
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i]["name"].(string) < methods[j]["name"].(string) })
+
+	schemas := make(map[string]interface{}, len(reached))
+	for name := range reached {
+		schemas[name] = doc.Components.Schemas[name]
+	}
+	doc.Info["x-source"] = source
+
+	out, err := json.MarshalIndent(map[string]interface{}{
+		"openrpc":    doc.OpenRPC,
+		"info":       doc.Info,
+		"methods":    methods,
+		"components": map[string]interface{}{"schemas": schemas},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, '\n')
+
+	// The subset must load like the embedded schemas do
+	if _, err := Parse(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// collectRefs marks the component schemas value refers to, directly or through other components.
+func collectRefs(value interface{}, schemas map[string]interface{}, reached map[string]bool) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			schema, ok := schemas[name]
+			if !ok {
+				return fmt.Errorf("unresolvable $ref %q", ref)
+			}
+			if !reached[name] {
+				reached[name] = true
+				if err := collectRefs(schema, schemas, reached); err != nil {
+					return err
+				}
+			}
+		}
+		for _, child := range value {
+			if err := collectRefs(child, schemas, reached); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			if err := collectRefs(child, schemas, reached); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
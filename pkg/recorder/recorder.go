@@ -0,0 +1,165 @@
+// Package recorder captures the HTTP traffic of a run into a bundle directory and serves a bundle back
+// through local servers, so a run can be reproduced without the original endpoint.
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/eth-error-tests/pkg/config"
+)
+
+const (
+	exchangesFile = "exchanges.jsonl"
+	sessionFile   = "session.json"
+)
+
+// Exchange is a single recorded HTTP request/response pair. Bodies are kept as strings because
+// not every response is JSON (e.g. HTTP 401 from the Engine API).
+type Exchange struct {
+	Seq      int    `json:"seq"`
+	URL      string `json:"url"`
+	Request  string `json:"request"`
+	Status   int    `json:"status"`
+	Response string `json:"response"`
+}
+
+// Session holds the run state that does not travel over HTTP but is needed to replay it.
+type Session struct {
+	Network    string `json:"network"`
+	Url        string `json:"url"`
+	EngineUrl  string `json:"engineUrl,omitempty"`
+	From       string `json:"from"`
+	DevAccount string `json:"devAccount,omitempty"`
+	JWTSecret  string `json:"jwtSecret,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that appends every exchange passing through it to the bundle.
+type Recorder struct {
+	base http.RoundTripper
+	dir  string
+
+	mu     sync.Mutex
+	seq    int
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func NewRecorder(dir string, base http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create record dir: %w", err)
+	}
+
+	file, err := os.Create(filepath.Join(dir, exchangesFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exchanges file: %w", err)
+	}
+
+	return &Recorder{
+		base:   base,
+		dir:    dir,
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+// Install routes http.DefaultTransport through a new Recorder. This covers both the raw requests and
+// ethclient, whose HTTP client falls back to the default transport.
+func Install(dir string) (*Recorder, error) {
+	r, err := NewRecorder(dir, http.DefaultTransport)
+	if err != nil {
+		return nil, err
+	}
+	http.DefaultTransport = r
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := r.write(Exchange{
+		URL:      req.URL.String(),
+		Request:  string(reqBody),
+		Status:   resp.StatusCode,
+		Response: string(respBody),
+	}); err != nil {
+		fmt.Printf("Warning: failed to record exchange: %v\n", err)
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) write(exchange Exchange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	exchange.Seq = r.seq
+	line, err := json.Marshal(exchange)
+	if err != nil {
+		return err
+	}
+	if _, err := r.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return r.writer.Flush()
+}
+
+// SaveSession writes the session next to the recorded exchanges.
+func (r *Recorder) SaveSession(session Session) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, sessionFile), data, 0o644)
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+	return r.file.Close()
+}
+
+// NewSession captures the replay-relevant state of cfg at the end of a run.
+func NewSession(cfg config.Config) Session {
+	return Session{
+		Network:    cfg.Network,
+		Url:        cfg.Url,
+		EngineUrl:  cfg.EngineUrl,
+		From:       cfg.From,
+		DevAccount: cfg.DevAccount,
+		JWTSecret:  cfg.JWTSecret,
+	}
+}
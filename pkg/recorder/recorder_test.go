@@ -0,0 +1,140 @@
+package recorder
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/eth-error-tests/pkg/runner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// captureStdout returns everything fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+func runTests(t *testing.T, cfg config.Config, testNames ...string) (string, config.Config) {
+	t.Helper()
+	r, err := runner.NewTestRunner(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(t, func() {
+		if err := r.RunWithAutoDeployment(testNames); err != nil {
+			t.Errorf("run failed: %v", err)
+		}
+	})
+	return out, r.Config()
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := mockrpc.NewServer(mockrpc.Profile{})
+	server.HandleDevChain(1337)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Config{
+		Network:    "mock",
+		Url:        url,
+		PrivateKey: common.Bytes2Hex(crypto.FromECDSA(key)),
+		ChainID:    1337,
+		ToContract: "0x00000000000000000000000000000000000000aa",
+		DevAccount: "0x00000000000000000000000000000000000000bb",
+	}
+
+	dir := t.TempDir()
+	base := http.DefaultTransport
+	rec, err := Install(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, finalCfg := runTests(t, cfg, "eth_getBalance", "eth_feeHistory")
+	http.DefaultTransport = base
+	if err := rec.SaveSession(NewSession(finalCfg)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	replayer, err := LoadReplay(dir)
+	if err != nil {
+		t.Fatalf("failed to load replay: %v", err)
+	}
+	defer replayer.Close()
+
+	replayCfg, err := replayer.Configure(cfg)
+	if err != nil {
+		t.Fatalf("failed to configure replay: %v", err)
+	}
+	if replayCfg.Url == url || !replayCfg.Replaying || replayCfg.DevAccount != cfg.DevAccount {
+		t.Fatalf("replay config not applied: %+v", replayCfg)
+	}
+
+	replayed, _ := runTests(t, replayCfg, "eth_getBalance", "eth_feeHistory")
+	if stripTimings(replayed) != stripTimings(recorded) {
+		t.Errorf("replayed output differs from the recording\nrecorded:\n%s\nreplayed:\n%s", recorded, replayed)
+	}
+}
+
+func TestReplayRepeatsLastMatchingExchange(t *testing.T) {
+	ep := &endpoint{
+		exchanges: []Exchange{
+			{Request: `{"method":"eth_getTransactionReceipt","id":1}`, Status: 200, Response: "null"},
+			{Request: `{"method":"eth_getTransactionReceipt","id":1}`, Status: 200, Response: "receipt"},
+			{Request: `{"method":"eth_blockNumber","id":1}`, Status: 200, Response: "0x1"},
+		},
+		used:      make([]bool, 3),
+		lastByKey: make(map[string]int),
+	}
+
+	want := []string{"null", "receipt", "receipt"}
+	for i, w := range want {
+		got, ok := ep.next(`{"method":"eth_getTransactionReceipt","id":1}`)
+		if !ok || got.Response != w {
+			t.Errorf("poll %d: got %q, want %q", i, got.Response, w)
+		}
+	}
+
+	if got, ok := ep.next(`{"method":"eth_blockNumber","id":7}`); !ok || got.Response != "0x1" {
+		t.Errorf("method fallback: got %q", got.Response)
+	}
+	if _, ok := ep.next(`{"method":"eth_chainId","id":1}`); ok {
+		t.Error("expected no match for an unrecorded method")
+	}
+}
+
+// stripTimings drops the "Test completed in" durations, which differ between runs.
+func stripTimings(out string) string {
+	return regexp.MustCompile(`Test completed in .*`).ReplaceAllString(out, "")
+}
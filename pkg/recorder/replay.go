@@ -0,0 +1,202 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/eth-error-tests/pkg/config"
+)
+
+// Replayer serves a recorded bundle back with one local server per recorded endpoint.
+//
+// A request is answered with the next unused exchange that has the same body. When every such exchange
+// was used the last one is repeated, since polling (e.g. waiting for a receipt) may take a different
+// number of rounds than in the recording. Requests that were never recorded fall back to the next
+// unused exchange calling the same JSON-RPC method(s), which tolerates small differences like ids.
+type Replayer struct {
+	Session Session
+
+	endpoints map[string]*endpoint
+	servers   []*http.Server
+}
+
+type endpoint struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+	used      []bool
+	lastByKey map[string]int
+}
+
+// LoadReplay reads a bundle written by Recorder.
+func LoadReplay(dir string) (*Replayer, error) {
+	data, err := os.ReadFile(filepath.Join(dir, sessionFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, exchangesFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open exchanges: %w", err)
+	}
+	defer file.Close()
+
+	endpoints := make(map[string]*endpoint)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var exchange Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &exchange); err != nil {
+			return nil, fmt.Errorf("failed to parse exchange: %w", err)
+		}
+		ep, ok := endpoints[exchange.URL]
+		if !ok {
+			ep = &endpoint{lastByKey: make(map[string]int)}
+			endpoints[exchange.URL] = ep
+		}
+		ep.exchanges = append(ep.exchanges, exchange)
+		ep.used = append(ep.used, false)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read exchanges: %w", err)
+	}
+
+	return &Replayer{Session: session, endpoints: endpoints}, nil
+}
+
+// Start serves every recorded endpoint on a local port and returns the original URL -> local URL mapping.
+func (r *Replayer) Start() (map[string]string, error) {
+	urls := make(map[string]string, len(r.endpoints))
+	for url, ep := range r.endpoints {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("failed to listen for %s: %w", url, err)
+		}
+
+		server := &http.Server{Handler: ep}
+		r.servers = append(r.servers, server)
+		go func() {
+			if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fmt.Printf("Replay server error: %v\n", err)
+			}
+		}()
+
+		urls[url] = "http://" + listener.Addr().String()
+	}
+	return urls, nil
+}
+
+func (r *Replayer) Close() {
+	for _, server := range r.servers {
+		if err := server.Close(); err != nil {
+			fmt.Printf("Warning: failed to stop replay server: %v\n", err)
+		}
+	}
+}
+
+func (ep *endpoint) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	exchange, ok := ep.next(string(body))
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":null,"error":{"code":-32000,"message":"replay: no recorded response"}}`)
+		return
+	}
+
+	if json.Valid([]byte(exchange.Response)) {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(exchange.Status)
+	fmt.Fprint(w, exchange.Response)
+}
+
+func (ep *endpoint) next(body string) (Exchange, bool) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	for i, exchange := range ep.exchanges {
+		if !ep.used[i] && exchange.Request == body {
+			return ep.use(i, body), true
+		}
+	}
+	if i, ok := ep.lastByKey[body]; ok {
+		return ep.exchanges[i], true
+	}
+
+	methods := methodsOf(body)
+	if methods == "" {
+		return Exchange{}, false
+	}
+	for i, exchange := range ep.exchanges {
+		if !ep.used[i] && methodsOf(exchange.Request) == methods {
+			return ep.use(i, body), true
+		}
+	}
+	return Exchange{}, false
+}
+
+func (ep *endpoint) use(i int, body string) Exchange {
+	ep.used[i] = true
+	ep.lastByKey[body] = i
+	return ep.exchanges[i]
+}
+
+// methodsOf returns the JSON-RPC method names of a single or batch request body.
+func methodsOf(body string) string {
+	var single struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal([]byte(body), &single); err == nil {
+		return single.Method
+	}
+
+	var batch []struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal([]byte(body), &batch); err != nil {
+		return ""
+	}
+	methods := ""
+	for _, call := range batch {
+		methods += call.Method + ","
+	}
+	return methods
+}
+
+// Configure starts the replay servers and points cfg at them. The session's dev account and JWT secret
+// are restored, and cfg is marked as replaying so no local node is started.
+func (r *Replayer) Configure(cfg config.Config) (config.Config, error) {
+	urls, err := r.Start()
+	if err != nil {
+		return cfg, err
+	}
+
+	url, ok := urls[r.Session.Url]
+	if !ok {
+		return cfg, fmt.Errorf("no exchanges recorded for %s", r.Session.Url)
+	}
+	cfg.Url = url
+	cfg.EngineUrl = urls[r.Session.EngineUrl]
+	cfg.DevAccount = r.Session.DevAccount
+	cfg.JWTSecret = r.Session.JWTSecret
+	cfg.Replaying = true
+
+	return cfg, nil
+}
@@ -1,17 +1,20 @@
 package runner
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/eth-error-tests/pkg/config"
 	"github.com/eth-error-tests/pkg/contract"
 	"github.com/eth-error-tests/pkg/deployer"
+	"github.com/eth-error-tests/pkg/jsonrpc"
 	"github.com/eth-error-tests/pkg/localnode"
 	"github.com/eth-error-tests/pkg/testcases"
 	pkgTypes "github.com/eth-error-tests/pkg/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type TestRunner struct {
@@ -55,13 +58,14 @@ func (r *TestRunner) DeployContracts() error {
 		contract.TestKeccak,
 	}
 
-	deployedContracts, errors := r.deployer.DeploySpecificContracts(contractsToDepl)
+	deployedContracts, deploymentTxs, errors := r.deployer.DeploySpecificContracts(contractsToDepl)
 	if len(errors) > 0 {
 		fmt.Printf("Warning: Some contracts failed to deploy: %v\n", errors)
 	}
 
 	r.deployedContracts = deployedContracts
 	r.config.DeployedContracts = deployedContracts
+	r.config.DeploymentTxs = deploymentTxs
 
 	// Update the config with deployed contract addresses
 	if storageAddr, ok := deployedContracts["storage"]; ok {
@@ -127,19 +131,59 @@ func (r *TestRunner) RunSpecificTest(testName string) error {
 	return nil
 }
 
-func (r *TestRunner) RunWithAutoDeployment(testNames []string) error {
-	if r.config.IsLocalNode() {
-		_, err := r.nodeManager.StartAndFund()
+// Setup starts the local node (if any), deploys the contracts unless ToContract is preset and, when running in
+// parallel, funds the sender accounts transaction scenarios are spread over.
+func (r *TestRunner) Setup() error {
+	if r.config.IsLocalNode() && !r.config.Replaying {
+		devAccount, err := r.nodeManager.StartAndFund()
 		if err != nil {
 			return fmt.Errorf("failed to start local node: %w", err)
 		}
-
+		r.config.DevAccount = devAccount
+		r.config.JWTSecret = r.nodeManager.JWTSecret()
 	}
 	if r.config.ToContract == "" {
 		if err := r.DeployContracts(); err != nil {
 			return fmt.Errorf("failed to deploy contracts: %w", err)
 		}
 	}
+	if r.config.Parallel > 1 && len(r.config.Senders) == 0 {
+		if err := r.setupSenders(); err != nil {
+			return fmt.Errorf("failed to set up sender accounts: %w", err)
+		}
+	}
+	return nil
+}
+
+// setupSenders derives one sender account per parallel lane from the private key, funds them and makes sure
+// each has sent a transaction.
+func (r *TestRunner) setupSenders() error {
+	senders, err := jsonrpc.DeriveSenders(r.config.PrivateKey, r.config.Parallel)
+	if err != nil {
+		return err
+	}
+
+	client, err := ethclient.Dial(r.config.Url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to node: %w", err)
+	}
+	defer client.Close()
+
+	if err := jsonrpc.FundSenders(context.Background(), client, r.config, senders); err != nil {
+		return err
+	}
+	if err := jsonrpc.AdvanceSenders(context.Background(), client, r.config, senders); err != nil {
+		return err
+	}
+	r.config.Senders = senders
+	fmt.Printf("Running up to %d scenarios in parallel from %d sender accounts\n", r.config.Parallel, len(senders))
+	return nil
+}
+
+func (r *TestRunner) RunWithAutoDeployment(testNames []string) error {
+	if err := r.Setup(); err != nil {
+		return err
+	}
 
 	if len(testNames) == 0 {
 		return r.RunAllTests()
@@ -169,3 +213,8 @@ func (r *TestRunner) Cleanup() {
 func (r *TestRunner) GetDeployedContracts() map[string]common.Address {
 	return r.deployedContracts
 }
+
+// Config returns the runner's config, including state discovered during the run (dev account, JWT secret).
+func (r *TestRunner) Config() config.Config {
+	return r.config
+}
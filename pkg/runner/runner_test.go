@@ -0,0 +1,150 @@
+package runner
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newMockRunner starts a mock dev chain and returns a runner pointed at it. ToContract is preset so
+// no deployment is attempted.
+func newMockRunner(t *testing.T) (*TestRunner, *mockrpc.Server) {
+	t.Helper()
+	server := mockrpc.NewServer(mockrpc.Profile{})
+	server.HandleDevChain(1337)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start mock server: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewTestRunner(config.Config{
+		Network:    "mock",
+		Url:        url,
+		PrivateKey: common.Bytes2Hex(crypto.FromECDSA(key)),
+		ChainID:    1337,
+		ToContract: "0x00000000000000000000000000000000000000aa",
+	})
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	if r.config.From != crypto.PubkeyToAddress(key.PublicKey).Hex() {
+		t.Errorf("From = %s, want the address derived from the private key", r.config.From)
+	}
+
+	return r, server
+}
+
+// silence discards stdout while fn runs.
+func silence(t *testing.T, fn func()) {
+	t.Helper()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+	fn()
+}
+
+func TestNewTestRunnerRequiresPrivateKey(t *testing.T) {
+	if _, err := NewTestRunner(config.Config{}); err == nil {
+		t.Error("expected an error without a private key")
+	}
+}
+
+func TestRunWithAutoDeployment(t *testing.T) {
+	r, server := newMockRunner(t)
+
+	var runErr error
+	silence(t, func() { runErr = r.RunWithAutoDeployment([]string{"eth_getBalance"}) })
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+
+	balanceCalls := 0
+	for _, req := range server.Requests() {
+		switch req.Method {
+		case "eth_getBalance":
+			balanceCalls++
+		case "eth_sendRawTransaction":
+			t.Errorf("unexpected deployment with ToContract set")
+		}
+	}
+	if balanceCalls == 0 {
+		t.Error("expected the eth_getBalance test case to reach the server")
+	}
+}
+
+func TestRunSpecificTestUnknown(t *testing.T) {
+	r, _ := newMockRunner(t)
+	if err := r.RunSpecificTest("eth_doesNotExist"); err == nil {
+		t.Error("expected an error for an unknown test case")
+	}
+}
+
+func TestSetupDerivesSenders(t *testing.T) {
+	r, server := newMockRunner(t)
+	r.config.Parallel = 3
+	// The nonce advances are mined right away
+	server.Handle("eth_getTransactionReceipt", func(req mockrpc.Request) (interface{}, *mockrpc.Error) {
+		var params []common.Hash
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			return nil, &mockrpc.Error{Code: -32602, Message: "invalid params"}
+		}
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: params[0], Logs: []*types.Log{}, BlockNumber: big.NewInt(2)}, nil
+	})
+
+	var setupErr error
+	silence(t, func() { setupErr = r.Setup() })
+	if setupErr != nil {
+		t.Fatalf("unexpected error: %v", setupErr)
+	}
+	if len(r.Config().Senders) != 3 {
+		t.Fatalf("senders = %d, want one per lane", len(r.Config().Senders))
+	}
+
+	// The mock accounts all hold the same balance, more than a share of the test account's, so no sender
+	// is topped up. With no transaction sent yet, each one advances its nonce.
+	senders := make(map[common.Address]bool)
+	for _, req := range server.Requests() {
+		if req.Method != "eth_sendRawTransaction" {
+			continue
+		}
+		var params []hexutil.Bytes
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			t.Fatalf("unexpected request: %+v", req)
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(params[0]); err != nil {
+			t.Fatal(err)
+		}
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if from.Hex() == r.config.From || *tx.To() != from {
+			t.Errorf("unexpected transaction from %s to %s", from.Hex(), tx.To().Hex())
+		}
+		senders[from] = true
+	}
+	if len(senders) != 3 {
+		t.Errorf("%d senders advanced their nonce, want 3", len(senders))
+	}
+}
@@ -0,0 +1,272 @@
+package testcases
+
+import (
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/contract"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// AccessListTestCase covers eth_createAccessList. Clients disagree on whether a reverting call
+// is returned as a JSON-RPC error or as a result carrying an `error` field.
+type AccessListTestCase struct{}
+
+func (t *AccessListTestCase) Name() string {
+	return "eth_createAccessList"
+}
+
+func (t *AccessListTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *AccessListTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	opcodes := cfg.DeployedContracts["opcodes"].Hex()
+	testKeccak := cfg.DeployedContracts["testkeccak"].Hex()
+	hashAndStore := inputHex(contract.TestKeccak, "hashAndStore", uint32(2), []byte{0x12, 0x34})
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": hashAndStore,
+					},
+					"latest",
+				},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   opcodes,
+						"data": inputHex(contract.OpCodes, "test"),
+					},
+					"latest",
+				},
+			},
+			Desc: "OpCodes test() touching many opcodes",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   opcodes,
+						"data": inputHex(contract.OpCodes, "test_revert"),
+					},
+					"latest",
+				},
+			},
+			Desc: "Reverting call (REVERT opcode)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   opcodes,
+						"data": inputHex(contract.OpCodes, "test_invalid"),
+					},
+					"latest",
+				},
+			},
+			Desc: "Reverting call (INVALID opcode)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": inputHex(contract.TestKeccak, "hash", uint32(0), []byte{0x12, 0x34}),
+					},
+					"latest",
+				},
+			},
+			Desc: "Reverting call (arithmetic underflow panic)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from":  cfg.From,
+						"to":    testKeccak,
+						"data":  hashAndStore,
+						"value": "0x1",
+					},
+					"latest",
+				},
+			},
+			Desc: "Value sent to non-payable function",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from":  cfg.From,
+						"to":    cfg.InvalidContract,
+						"value": "0xffffffffffffffffffffffffffffffffffffffff",
+					},
+					"latest",
+				},
+			},
+			Desc: "Insufficient funds for value",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": hashAndStore,
+					},
+					"unsupported",
+				},
+			},
+			Desc: "Invalid block tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": hashAndStore,
+					},
+					"0xffffffff",
+				},
+			},
+			Desc: "Future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": hashAndStore,
+						"gas":  "0x1",
+					},
+					"latest",
+				},
+			},
+			Desc: "Gas below intrinsic gas",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": hashAndStore,
+						"gas":  "0xffffffffffffffff",
+					},
+					"latest",
+				},
+			},
+			Desc: "Gas above RPC gas cap",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   testKeccak,
+						"data": inputHex(contract.TestKeccak, "hash", uint32(10_000_000), []byte{0x12, 0x34}),
+						"gas":  "0x5f5e100",
+					},
+					"latest",
+				},
+			},
+			Desc: "Call exhausting the gas cap",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_createAccessList",
+				Params: []interface{}{
+					map[string]string{
+						"from":         cfg.From,
+						"to":           testKeccak,
+						"data":         hashAndStore,
+						"gasPrice":     "0x1",
+						"maxFeePerGas": "0x1",
+					},
+					"latest",
+				},
+			},
+			Desc: "Both gasPrice and maxFeePerGas set",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_createAccessList",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "Invalid params types",
+		},
+	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_createAccessList", 100, func(block interface{}) []interface{} {
+		return []interface{}{
+			map[string]string{
+				"from": cfg.From,
+				"to":   testKeccak,
+				"data": hashAndStore,
+			},
+			block,
+		}
+	})...)
+}
+
+func (t *AccessListTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewAccessListTestCase() pkgTypes.TestCase {
+	return &AccessListTestCase{}
+}
@@ -17,7 +17,7 @@ func (t *BalanceTestCase) RequiresContract() bool {
 }
 
 func (t *BalanceTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
-	return []pkgTypes.Meta{
+	requests := []pkgTypes.Meta{
 		{
 			JsonRpcRequest: pkgTypes.JsonRpcRequest{
 				JsonRpc: "2.0",
@@ -37,6 +37,10 @@ func (t *BalanceTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
 			Desc: "Invalid account format",
 		},
 	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_getBalance", 100, func(block interface{}) []interface{} {
+		return []interface{}{cfg.From, block}
+	})...)
 }
 
 func (t *BalanceTestCase) Execute(cfg config.Config) {
@@ -0,0 +1,266 @@
+package testcases
+
+import (
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// BasicTestCase covers the web3_, net_ and parameterless eth_ methods.
+type BasicTestCase struct{}
+
+func (t *BasicTestCase) Name() string {
+	return "web3_clientVersion"
+}
+
+func (t *BasicTestCase) RequiresContract() bool {
+	return false
+}
+
+func (t *BasicTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	return []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "web3_clientVersion",
+				Params:  []interface{}{},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "web3_clientVersion",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "web3_clientVersion unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"0x68656c6c6f"},
+			},
+			Desc: "web3_sha3 proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"0x"},
+			},
+			Desc: "web3_sha3 empty input",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"0xzz"},
+			},
+			Desc: "web3_sha3 invalid hex",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"0x123"},
+			},
+			Desc: "web3_sha3 odd length hex",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"68656c6c6f"},
+			},
+			Desc: "web3_sha3 missing 0x prefix",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"hello"},
+			},
+			Desc: "web3_sha3 plain string",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "web3_sha3",
+				Params:  []interface{}{0x1234},
+			},
+			Desc: "web3_sha3 integer input",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "web3_sha3",
+				Params:  []interface{}{},
+			},
+			Desc: "web3_sha3 missing input",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "web3_sha3",
+				Params:  []interface{}{"0x68656c6c6f", "0x68656c6c6f"},
+			},
+			Desc: "web3_sha3 too many arguments",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "net_version",
+				Params:  []interface{}{},
+			},
+			Desc: "net_version proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "net_version",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "net_version unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "net_listening",
+				Params:  []interface{}{},
+			},
+			Desc: "net_listening proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "net_listening",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "net_listening unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "net_peerCount",
+				Params:  []interface{}{},
+			},
+			Desc: "net_peerCount proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "net_peerCount",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "net_peerCount unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_syncing",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_syncing proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_syncing",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_syncing unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_chainId",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_chainId proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "eth_chainId",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_chainId unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "eth_accounts",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_accounts proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "eth_accounts",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_accounts unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "eth_coinbase",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_coinbase proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "eth_coinbase",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_coinbase unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      26,
+				Method:  "net_wrongVersion",
+				Params:  []interface{}{},
+			},
+			Desc: "Incorrect method name",
+		},
+	}
+}
+
+func (t *BasicTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewBasicTestCase() pkgTypes.TestCase {
+	return &BasicTestCase{}
+}
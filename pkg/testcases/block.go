@@ -0,0 +1,358 @@
+package testcases
+
+import (
+	"fmt"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// BlockTestCase covers block retrieval. Some clients return null for unknown blocks and others error.
+type BlockTestCase struct{}
+
+func (t *BlockTestCase) Name() string {
+	return "eth_getBlockByNumber"
+}
+
+func (t *BlockTestCase) RequiresContract() bool {
+	return false
+}
+
+func (t *BlockTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	_, latestHash, err := latestBlock(cfg)
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"latest", false},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "Missing fullTx flag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"latest", "true"},
+			},
+			Desc: "Non-boolean fullTx (string)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"latest", 1},
+			},
+			Desc: "Non-boolean fullTx (integer)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"latest", true},
+			},
+			Desc: "Full transactions",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"0xffffffff", false},
+			},
+			Desc: "Unknown (future) block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"earliest", false},
+			},
+			Desc: "earliest tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"pending", false},
+			},
+			Desc: "pending tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"safe", false},
+			},
+			Desc: "safe tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"finalized", false},
+			},
+			Desc: "finalized tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"unsupported", false},
+			},
+			Desc: "Invalid block tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{0xabcdef, false},
+			},
+			Desc: "Invalid block parameter format",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_getBlockByNumber",
+				Params:  []interface{}{"latest", false, false},
+			},
+			Desc: "Too many arguments",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_getBlockByHash",
+				Params:  []interface{}{latestHash, false},
+			},
+			Desc: "eth_getBlockByHash proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_getBlockByHash",
+				Params:  []interface{}{latestHash},
+			},
+			Desc: "eth_getBlockByHash missing fullTx flag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_getBlockByHash",
+				Params:  []interface{}{latestHash, "false"},
+			},
+			Desc: "eth_getBlockByHash non-boolean fullTx",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_getBlockByHash",
+				Params:  []interface{}{unknownBlockHash, false},
+			},
+			Desc: "eth_getBlockByHash unknown block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_getBlockByHash",
+				Params:  []interface{}{"0x1234", false},
+			},
+			Desc: "eth_getBlockByHash hash wrong length",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_getBlockByHash",
+				Params:  []interface{}{"latest", false},
+			},
+			Desc: "eth_getBlockByHash with block tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_getBlockTransactionCountByNumber",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_getBlockTransactionCountByNumber proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "eth_getBlockTransactionCountByNumber",
+				Params:  []interface{}{"pending"},
+			},
+			Desc: "eth_getBlockTransactionCountByNumber pending tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "eth_getBlockTransactionCountByNumber",
+				Params:  []interface{}{"0xffffffff"},
+			},
+			Desc: "eth_getBlockTransactionCountByNumber unknown block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "eth_getBlockTransactionCountByNumber",
+				Params:  []interface{}{"unsupported"},
+			},
+			Desc: "eth_getBlockTransactionCountByNumber invalid block tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "eth_getBlockTransactionCountByNumber",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_getBlockTransactionCountByNumber missing block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "eth_getBlockTransactionCountByHash",
+				Params:  []interface{}{latestHash},
+			},
+			Desc: "eth_getBlockTransactionCountByHash proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      26,
+				Method:  "eth_getBlockTransactionCountByHash",
+				Params:  []interface{}{unknownBlockHash},
+			},
+			Desc: "eth_getBlockTransactionCountByHash unknown block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      27,
+				Method:  "eth_getBlockTransactionCountByHash",
+				Params:  []interface{}{"0x1234"},
+			},
+			Desc: "eth_getBlockTransactionCountByHash hash wrong length",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      28,
+				Method:  "eth_getUncleByBlockNumberAndIndex",
+				Params:  []interface{}{"latest", "0x0"},
+			},
+			Desc: "eth_getUncleByBlockNumberAndIndex no uncles",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      29,
+				Method:  "eth_getUncleByBlockNumberAndIndex",
+				Params:  []interface{}{"0xffffffff", "0x0"},
+			},
+			Desc: "eth_getUncleByBlockNumberAndIndex unknown block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      30,
+				Method:  "eth_getUncleByBlockNumberAndIndex",
+				Params:  []interface{}{"latest", 0},
+			},
+			Desc: "eth_getUncleByBlockNumberAndIndex index as integer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      31,
+				Method:  "eth_getUncleByBlockNumberAndIndex",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_getUncleByBlockNumberAndIndex missing index",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      32,
+				Method:  "eth_getUncleCountByBlockNumber",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_getUncleCountByBlockNumber proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      33,
+				Method:  "eth_blockNumber",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_blockNumber proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      34,
+				Method:  "eth_blockNumber",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_blockNumber unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      35,
+				Method:  "eth_wrongBlockNumber",
+				Params:  []interface{}{},
+			},
+			Desc: "Incorrect method name",
+		},
+	}
+
+	// Without a head block the hash scenarios would send an empty hash
+	if err != nil {
+		fmt.Printf("Warning: skipping the %s scenarios on the head block hash: %v\n", t.Name(), err)
+		return withoutIDs(requests, 14, 15, 16, 25)
+	}
+	return requests
+}
+
+func (t *BlockTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewBlockTestCase() pkgTypes.TestCase {
+	return &BlockTestCase{}
+}
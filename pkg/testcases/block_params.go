@@ -0,0 +1,97 @@
+package testcases
+
+import (
+	"fmt"
+
+	"github.com/eth-error-tests/pkg/config"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// unknownBlockHash is a well-formed hash that does not belong to any block
+const unknownBlockHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+// BlockParamBuilder returns the full params array for a method with block placed in its block parameter position.
+type BlockParamBuilder func(block interface{}) []interface{}
+
+// BlockParamRequests generates the shared block parameter and EIP-1898 block identifier scenarios
+// for any method that accepts a block parameter. Ids are assigned sequentially starting at firstID.
+func BlockParamRequests(cfg config.Config, method string, firstID int, build BlockParamBuilder) []pkgTypes.Meta {
+	latestNumber, latestHash, err := latestBlock(cfg)
+	if err != nil {
+		fmt.Printf("Warning: skipping the %s EIP-1898 scenarios that target the head block: %v\n", method, err)
+	}
+
+	blocks := []struct {
+		block     interface{}
+		desc      string
+		needsHead bool
+	}{
+		{"earliest", "earliest tag", false},
+		{"pending", "pending tag", false},
+		{"safe", "safe tag", false},
+		{"finalized", "finalized tag", false},
+		{"LATEST", "uppercase tag", false},
+		{"0xffffffff", "future block number", false},
+		{"0x10000000000000000", "block number above uint64", false},
+		{"0x01", "non-canonical hex (leading zero)", false},
+		{"0x", "empty hex", false},
+		{"1", "decimal string", false},
+		{"-0x1", "negative block number", false},
+		{map[string]interface{}{"blockNumber": latestNumber}, "EIP-1898 blockNumber", true},
+		{map[string]interface{}{"blockHash": latestHash}, "EIP-1898 blockHash", true},
+		{map[string]interface{}{"blockHash": latestHash, "requireCanonical": true}, "EIP-1898 blockHash with requireCanonical true", true},
+		{map[string]interface{}{"blockHash": latestHash, "requireCanonical": false}, "EIP-1898 blockHash with requireCanonical false", true},
+		{map[string]interface{}{"blockHash": latestHash, "requireCanonical": "yes"}, "EIP-1898 non-boolean requireCanonical", true},
+		{map[string]interface{}{"blockHash": unknownBlockHash}, "EIP-1898 unknown blockHash", false},
+		{map[string]interface{}{"blockHash": unknownBlockHash, "requireCanonical": true}, "EIP-1898 unknown blockHash with requireCanonical true", false},
+		{map[string]interface{}{"blockHash": "0x1234"}, "EIP-1898 blockHash wrong length", false},
+		{map[string]interface{}{"blockNumber": latestNumber, "blockHash": latestHash}, "EIP-1898 both blockNumber and blockHash", true},
+		{map[string]interface{}{"blockNumber": "0xffffffff"}, "EIP-1898 future blockNumber", false},
+		{map[string]interface{}{}, "EIP-1898 empty object", false},
+	}
+
+	requests := make([]pkgTypes.Meta, 0, len(blocks))
+	for i, b := range blocks {
+		// Ids stay those of a run with a head block, so the remaining scenarios line up across runs
+		if b.needsHead && err != nil {
+			continue
+		}
+		requests = append(requests, pkgTypes.Meta{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      firstID + i,
+				Method:  method,
+				Params:  build(b.block),
+			},
+			Desc: "Block param: " + b.desc,
+		})
+	}
+
+	return requests
+}
+
+// latestBlock looks up the number and hash of the current head so the EIP-1898 scenarios target a real block.
+func latestBlock(cfg config.Config) (string, string, error) {
+	var block struct {
+		Number string `json:"number"`
+		Hash   string `json:"hash"`
+	}
+	if err := rpcResult(cfg, "eth_getBlockByNumber", []interface{}{"latest", false}, &block); err != nil {
+		return "", "", fmt.Errorf("head block lookup failed: %w", err)
+	}
+	if block.Number == "" || block.Hash == "" {
+		return "", "", fmt.Errorf("head block has no number or hash")
+	}
+
+	return block.Number, block.Hash, nil
+}
+
+// headNumber returns the number of the current head block.
+func headNumber(cfg config.Config) (uint64, error) {
+	number, _, err := latestBlock(cfg)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.DecodeUint64(number)
+}
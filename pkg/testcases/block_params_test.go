@@ -0,0 +1,52 @@
+package testcases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+)
+
+func TestBlockParamRequestsHeadBlock(t *testing.T) {
+	build := func(block interface{}) []interface{} { return []interface{}{block} }
+	countHeadScenarios := func(devChain bool) (int, int) {
+		t.Helper()
+		server := mockrpc.NewServer(mockrpc.Profile{})
+		if devChain {
+			server.HandleDevChain(1337)
+		}
+		url, err := server.Start("127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer server.Close()
+
+		requests := BlockParamRequests(config.Config{Url: url}, "eth_getBalance", 1, build)
+		head := 0
+		for _, request := range requests {
+			block, ok := request.Params[0].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, key := range []string{"blockNumber", "blockHash"} {
+				if value, ok := block[key].(string); ok && value == "" {
+					t.Errorf("%s: empty %s", request.Desc, key)
+				}
+			}
+			if strings.Contains(request.Desc, "EIP-1898 blockNumber") || request.Desc == "Block param: EIP-1898 blockHash" {
+				head++
+			}
+		}
+		return len(requests), head
+	}
+
+	total, head := countHeadScenarios(true)
+	if head != 2 {
+		t.Fatalf("head block scenarios = %d, want 2", head)
+	}
+	withoutHead, head := countHeadScenarios(false)
+	if head != 0 || withoutHead != total-6 {
+		t.Errorf("without a head block: %d scenarios (%d on the head), want %d", withoutHead, head, total-6)
+	}
+}
@@ -0,0 +1,41 @@
+package testcases
+
+import (
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+)
+
+func TestBlockSkipsHeadHashScenarios(t *testing.T) {
+	for _, devChain := range []bool{true, false} {
+		server := mockrpc.NewServer(mockrpc.Profile{})
+		if devChain {
+			server.HandleDevChain(1337)
+		}
+		url, err := server.Start("127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var requests []int
+		output := captureStdout(t, func() {
+			for _, request := range NewBlockTestCase().GetRequests(config.Config{Url: url}) {
+				for _, param := range request.Params {
+					if param == "" {
+						t.Errorf("%s: empty param", request.Desc)
+					}
+				}
+				requests = append(requests, request.Id)
+			}
+		})
+		server.Close()
+
+		if devChain && len(requests) != 35 {
+			t.Errorf("with a head block: %d scenarios, want 35", len(requests))
+		}
+		if !devChain && len(requests) != 31 {
+			t.Errorf("without a head block: %d scenarios, want 31:\n%s", len(requests), output)
+		}
+	}
+}
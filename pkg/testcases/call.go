@@ -17,7 +17,7 @@ func (t *CallTestCase) RequiresContract() bool {
 }
 
 func (t *CallTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
-	return []pkgTypes.Meta{
+	requests := []pkgTypes.Meta{
 		{
 			JsonRpcRequest: pkgTypes.JsonRpcRequest{
 				JsonRpc: "2.0",
@@ -136,7 +136,362 @@ func (t *CallTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
 			},
 			Desc: "Invalid block parameter format",
 		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.From: map[string]string{
+							"balance": "0xffffffffffffffffffffffffffffffff",
+						},
+					},
+				},
+			},
+			Desc: "State override: balance",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"from": cfg.From,
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.From: map[string]string{
+							"nonce": "0xffffffffffffffff",
+						},
+					},
+				},
+			},
+			Desc: "State override: nonce at uint64 max",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.ToContract: map[string]string{
+							// PUSH1 0x2a PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 RETURN
+							"code": "0x602a60005260206000f3",
+						},
+					},
+				},
+			},
+			Desc: "State override: code",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.ToContract: map[string]interface{}{
+							"code": "0x123invalid",
+						},
+					},
+				},
+			},
+			Desc: "State override: invalid code hex",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.ToContract: map[string]interface{}{
+							"stateDiff": map[string]string{
+								"0x0000000000000000000000000000000000000000000000000000000000000000": "0x000000000000000000000000000000000000000000000000000000000000002a",
+							},
+						},
+					},
+				},
+			},
+			Desc: "State override: stateDiff on storage slot 0",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.ToContract: map[string]interface{}{
+							"state": map[string]string{
+								"0x0000000000000000000000000000000000000000000000000000000000000000": "0x000000000000000000000000000000000000000000000000000000000000002a",
+							},
+							"stateDiff": map[string]string{
+								"0x0000000000000000000000000000000000000000000000000000000000000000": "0x0000000000000000000000000000000000000000000000000000000000000001",
+							},
+						},
+					},
+				},
+			},
+			Desc: "State override: both state and stateDiff set",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.ToContract: map[string]interface{}{
+							"stateDiff": map[string]string{
+								"0x00": "0x2a",
+							},
+						},
+					},
+				},
+			},
+			Desc: "State override: storage key not 32 bytes",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						"0x1234": map[string]string{
+							"balance": "0x1",
+						},
+					},
+				},
+			},
+			Desc: "State override: invalid address key",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{
+						cfg.ToContract: map[string]string{
+							"unknownField": "0x1",
+						},
+					},
+				},
+			},
+			Desc: "State override: unknown account field",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					"0x1234",
+				},
+			},
+			Desc: "State override: malformed override object",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   "0x0000000000000000000000000000000000000001",
+						"data": "0x",
+					},
+					"latest",
+					map[string]interface{}{
+						"0x0000000000000000000000000000000000000001": map[string]string{
+							"code": "0x602a60005260206000f3",
+						},
+					},
+				},
+			},
+			Desc: "State override: code on ecrecover precompile",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   "0x0000000000000000000000000000000000000123",
+						"data": "0x",
+					},
+					"latest",
+					map[string]interface{}{
+						"0x0000000000000000000000000000000000000002": map[string]string{
+							"movePrecompileToAddress": "0x0000000000000000000000000000000000000123",
+						},
+					},
+				},
+			},
+			Desc: "State override: move sha256 precompile",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{},
+					map[string]string{
+						"number":        "0xffffff",
+						"time":          "0xffffffff",
+						"gasLimit":      "0x1c9c380",
+						"feeRecipient":  "0x0000000000000000000000000000000000000001",
+						"baseFeePerGas": "0x0",
+					},
+				},
+			},
+			Desc: "Block override: number, time, gasLimit, feeRecipient and baseFeePerGas",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{},
+					map[string]string{
+						"number": "0x0",
+					},
+				},
+			},
+			Desc: "Block override: number set to genesis",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{},
+					map[string]string{
+						"unknownField": "0x1",
+					},
+				},
+			},
+			Desc: "Block override: unknown field",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      26,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					map[string]interface{}{},
+					"latest",
+				},
+			},
+			Desc: "Block override: malformed override object",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      27,
+				Method:  "eth_call",
+				Params: []interface{}{
+					map[string]string{
+						"to":   cfg.ToContract,
+						"data": "0x2e64cec1",
+					},
+					"latest",
+					nil,
+					map[string]string{
+						"number": "0x123invalid",
+					},
+				},
+			},
+			Desc: "Block override: null state override with invalid number",
+		},
 	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_call", 100, func(block interface{}) []interface{} {
+		return []interface{}{
+			map[string]string{
+				"to":   cfg.ToContract,
+				"data": "0x2e64cec1",
+			},
+			block,
+		}
+	})...)
 }
 
 func (t *CallTestCase) Execute(cfg config.Config) {
@@ -17,7 +17,7 @@ func (t *CodeAtTestCase) RequiresContract() bool {
 }
 
 func (t *CodeAtTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
-	return []pkgTypes.Meta{
+	requests := []pkgTypes.Meta{
 		{
 			JsonRpcRequest: pkgTypes.JsonRpcRequest{
 				JsonRpc: "2.0",
@@ -82,6 +82,10 @@ func (t *CodeAtTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
 			Desc: "Too many arguments",
 		},
 	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_getCode", 100, func(block interface{}) []interface{} {
+		return []interface{}{cfg.ToContract, block}
+	})...)
 }
 
 func (t *CodeAtTestCase) Execute(cfg config.Config) {
@@ -0,0 +1,433 @@
+package testcases
+
+import (
+	"fmt"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/contract"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// DebugTraceTestCase covers the debug_ and trace_ namespaces. trace_ methods are only served by
+// some clients (e.g. besu with the TRACE api enabled); others are expected to return method not found.
+type DebugTraceTestCase struct {
+	revertedTx string // hash of a mined OpCodes.test_revert() tx, set by Execute
+}
+
+func (t *DebugTraceTestCase) Name() string {
+	return "debug_traceTransaction"
+}
+
+func (t *DebugTraceTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *DebugTraceTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	deploymentTx := knownTransaction(cfg).Hash
+	revertedTx := t.revertedTx
+	if revertedTx == "" {
+		revertedTx = unknownTxHash
+	}
+
+	retrieveCall := map[string]string{
+		"from": cfg.From,
+		"to":   cfg.ToContract,
+		"data": inputHex(contract.Storage, "retrieve"),
+	}
+	revertCall := map[string]string{
+		"from": cfg.From,
+		"to":   cfg.DeployedContracts["opcodes"].Hex(),
+		"data": inputHex(contract.OpCodes, "test_revert"),
+	}
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{deploymentTx, map[string]interface{}{}},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{revertedTx, map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "Reverted OpCodes tx with callTracer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{revertedTx, map[string]interface{}{"disableStorage": true, "disableStack": true}},
+			},
+			Desc: "Reverted OpCodes tx with struct logger",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{deploymentTx, map[string]interface{}{"tracer": "unknownTracer"}},
+			},
+			Desc: "Unknown tracer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{deploymentTx, map[string]interface{}{"tracer": "{invalid js"}},
+			},
+			Desc: "Invalid JS tracer source",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{deploymentTx, map[string]interface{}{"tracer": "callTracer", "tracerConfig": "notAnObject"}},
+			},
+			Desc: "Invalid tracer config JSON",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{deploymentTx, map[string]interface{}{"tracer": "callTracer", "tracerConfig": map[string]interface{}{"onlyTopCall": "yes"}}},
+			},
+			Desc: "Invalid tracer config field type",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{deploymentTx, map[string]interface{}{"tracer": "prestateTracer", "tracerConfig": map[string]interface{}{"unknownOption": true}}},
+			},
+			Desc: "Unknown tracer config field",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{revertedTx, map[string]interface{}{"timeout": "1ns"}},
+			},
+			Desc: "Timeout exceeded (1ns)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{revertedTx, map[string]interface{}{"timeout": "abc"}},
+			},
+			Desc: "Invalid timeout format",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{unknownTxHash, map[string]interface{}{}},
+			},
+			Desc: "Unknown transaction hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{"0x1234", map[string]interface{}{}},
+			},
+			Desc: "Transaction hash wrong length",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "debug_traceTransaction",
+				Params:  []interface{}{},
+			},
+			Desc: "Missing transaction hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall, "latest", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceCall proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{revertCall, "latest", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceCall reverting call",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall, "latest", map[string]interface{}{"tracer": "unknownTracer"}},
+			},
+			Desc: "debug_traceCall unknown tracer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall, "latest", map[string]interface{}{"tracer": "callTracer", "tracerConfig": "notAnObject"}},
+			},
+			Desc: "debug_traceCall invalid tracer config JSON",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall, "0xffffffff", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceCall future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall, "latest", map[string]interface{}{"tracer": "callTracer", "stateOverrides": map[string]interface{}{cfg.ToContract: map[string]string{"code": "0x602a60005260206000f3"}}}},
+			},
+			Desc: "debug_traceCall with state overrides",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall, "latest", map[string]interface{}{"timeout": "1ns"}},
+			},
+			Desc: "debug_traceCall timeout exceeded (1ns)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "debug_traceCall",
+				Params:  []interface{}{retrieveCall},
+			},
+			Desc: "debug_traceCall missing block parameter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "debug_traceBlockByNumber",
+				Params:  []interface{}{"latest", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceBlockByNumber proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "debug_traceBlockByNumber",
+				Params:  []interface{}{"pending", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceBlockByNumber pending block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "debug_traceBlockByNumber",
+				Params:  []interface{}{"0xffffffff", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceBlockByNumber future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "debug_traceBlockByNumber",
+				Params:  []interface{}{"latest", map[string]interface{}{"tracer": "unknownTracer"}},
+			},
+			Desc: "debug_traceBlockByNumber unknown tracer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      26,
+				Method:  "debug_traceBlockByNumber",
+				Params:  []interface{}{"0x0", map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceBlockByNumber genesis block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      27,
+				Method:  "debug_traceBlockByHash",
+				Params:  []interface{}{unknownBlockHash, map[string]interface{}{"tracer": "callTracer"}},
+			},
+			Desc: "debug_traceBlockByHash unknown block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      28,
+				Method:  "trace_transaction",
+				Params:  []interface{}{deploymentTx},
+			},
+			Desc: "trace_transaction proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      29,
+				Method:  "trace_transaction",
+				Params:  []interface{}{revertedTx},
+			},
+			Desc: "trace_transaction reverted OpCodes tx",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      30,
+				Method:  "trace_transaction",
+				Params:  []interface{}{unknownTxHash},
+			},
+			Desc: "trace_transaction unknown transaction hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      31,
+				Method:  "trace_replayTransaction",
+				Params:  []interface{}{revertedTx, []interface{}{"trace", "stateDiff"}},
+			},
+			Desc: "trace_replayTransaction reverted OpCodes tx",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      32,
+				Method:  "trace_replayTransaction",
+				Params:  []interface{}{revertedTx, []interface{}{"unknownType"}},
+			},
+			Desc: "trace_replayTransaction unknown trace type",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      33,
+				Method:  "trace_call",
+				Params:  []interface{}{retrieveCall, []interface{}{"trace"}, "latest"},
+			},
+			Desc: "trace_call proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      34,
+				Method:  "trace_call",
+				Params:  []interface{}{revertCall, []interface{}{"trace"}, "latest"},
+			},
+			Desc: "trace_call reverting call",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      35,
+				Method:  "trace_call",
+				Params:  []interface{}{retrieveCall, []interface{}{"unknownType"}, "latest"},
+			},
+			Desc: "trace_call unknown trace type",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      36,
+				Method:  "trace_block",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "trace_block proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      37,
+				Method:  "trace_block",
+				Params:  []interface{}{"0xffffffff"},
+			},
+			Desc: "trace_block future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      38,
+				Method:  "trace_filter",
+				Params:  []interface{}{map[string]interface{}{"fromBlock": "0x2", "toBlock": "0x1"}},
+			},
+			Desc: "trace_filter fromBlock greater than toBlock",
+		},
+	}
+
+	return append(requests, BlockParamRequests(cfg, "debug_traceCall", 100, func(block interface{}) []interface{} {
+		return []interface{}{retrieveCall, block, map[string]interface{}{"tracer": "callTracer"}}
+	})...)
+}
+
+func (t *DebugTraceTestCase) Execute(cfg config.Config) {
+	revertedTx, err := sendRevertedTx(cfg)
+	if err != nil {
+		fmt.Println("Error sending reverted transaction:", err)
+	}
+	t.revertedTx = revertedTx
+
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+// sendRevertedTx mines a call to OpCodes.test_revert() so there is a failed transaction to trace.
+// The gas limit is fixed because estimating a reverting call fails.
+func sendRevertedTx(cfg config.Config) (string, error) {
+	revertInput, err := contract.BuildInput(contract.OpCodes, "test_revert")
+	if err != nil {
+		return "", fmt.Errorf("error building input: %w", err)
+	}
+
+	client, tx, err := sendTx(cfg,
+		jsonrpc.DataModifier(revertInput),
+		jsonrpc.ToAddressModifier(cfg, cfg.DeployedContracts["opcodes"].Hex(), nil),
+		jsonrpc.GasLimitModifier(cfg, 100_000, nil),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	txHash := tx.Hash().Hex()
+	if _, err := jsonrpc.WaitForTransaction(client, txHash); err != nil {
+		return txHash, err
+	}
+
+	return txHash, nil
+}
+
+func NewDebugTraceTestCase() pkgTypes.TestCase {
+	return &DebugTraceTestCase{}
+}
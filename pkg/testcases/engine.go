@@ -0,0 +1,360 @@
+package testcases
+
+import (
+	"fmt"
+	"net"
+	neturl "net/url"
+	"time"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+const (
+	zeroHash = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+	// wrongJWTSecret is a valid secret that the node does not know
+	wrongJWTSecret = "0x3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+// EngineTestCase covers the Engine API on the authenticated port of local nodes. The error codes are
+// specified in https://github.com/ethereum/execution-apis/blob/main/src/engine/common.md#errors
+// and are asserted through ExpectedCode.
+type EngineTestCase struct{}
+
+func (t *EngineTestCase) Name() string {
+	return "engine_exchangeCapabilities"
+}
+
+func (t *EngineTestCase) RequiresContract() bool {
+	return false
+}
+
+func (t *EngineTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	_, headHash, err := latestBlock(cfg)
+	tokens := engineTokens(cfg)
+
+	manyHashes := make([]interface{}, 1025)
+	for i := range manyHashes {
+		manyHashes[i] = unknownBlockHash
+	}
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{[]interface{}{"engine_newPayloadV3", "engine_forkchoiceUpdatedV3", "engine_getPayloadV3"}},
+			},
+			Desc:    "Proper request",
+			Headers: tokens["valid"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{[]interface{}{"engine_newPayloadV3"}},
+			},
+			Desc: "Missing JWT",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{[]interface{}{"engine_newPayloadV3"}},
+			},
+			Desc:    "Expired JWT (iat 2 minutes ago)",
+			Headers: tokens["expired"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{[]interface{}{"engine_newPayloadV3"}},
+			},
+			Desc:    "JWT issued in the future (iat in 2 minutes)",
+			Headers: tokens["future"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{[]interface{}{"engine_newPayloadV3"}},
+			},
+			Desc:    "JWT signed with the wrong secret",
+			Headers: tokens["wrongSecret"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{[]interface{}{"engine_newPayloadV3"}},
+			},
+			Desc:    "Malformed JWT",
+			Headers: tokens["malformed"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "engine_exchangeCapabilities",
+				Params:  []interface{}{"engine_newPayloadV3"},
+			},
+			Desc:         "engine_exchangeCapabilities invalid params type",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32602,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "engine_getPayloadV3",
+				Params:  []interface{}{"0x0000000000000001"},
+			},
+			Desc:         "engine_getPayloadV3 unknown payload ID",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38001,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "engine_getPayloadV2",
+				Params:  []interface{}{"0x0000000000000001"},
+			},
+			Desc:         "engine_getPayloadV2 unknown payload ID",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38001,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "engine_getPayloadV3",
+				Params:  []interface{}{"0x01"},
+			},
+			Desc:         "engine_getPayloadV3 payload ID wrong length",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32602,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "engine_forkchoiceUpdatedV3",
+				Params:  []interface{}{map[string]string{"headBlockHash": headHash, "safeBlockHash": unknownBlockHash, "finalizedBlockHash": unknownBlockHash}, nil},
+			},
+			Desc:         "engine_forkchoiceUpdatedV3 invalid forkchoice state",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38002,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "engine_forkchoiceUpdatedV3",
+				Params:  []interface{}{map[string]string{"headBlockHash": headHash, "safeBlockHash": headHash, "finalizedBlockHash": headHash}, map[string]interface{}{"timestamp": "0x0", "prevRandao": zeroHash, "suggestedFeeRecipient": cfg.From, "withdrawals": []interface{}{}, "parentBeaconBlockRoot": zeroHash}},
+			},
+			Desc:         "engine_forkchoiceUpdatedV3 invalid payload attributes (timestamp not after parent)",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38003,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "engine_forkchoiceUpdatedV3",
+				Params:  []interface{}{map[string]string{"headBlockHash": headHash, "safeBlockHash": headHash, "finalizedBlockHash": headHash}, map[string]interface{}{"timestamp": "0xffffffff", "prevRandao": zeroHash, "suggestedFeeRecipient": cfg.From}},
+			},
+			Desc:         "engine_forkchoiceUpdatedV3 payload attributes missing withdrawals and beacon root",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32602,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "engine_forkchoiceUpdatedV3",
+				Params:  []interface{}{map[string]string{"headBlockHash": unknownBlockHash, "safeBlockHash": zeroHash, "finalizedBlockHash": zeroHash}, nil},
+			},
+			Desc:    "engine_forkchoiceUpdatedV3 unknown head (SYNCING)",
+			Headers: tokens["valid"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "engine_forkchoiceUpdatedV1",
+				Params:  []interface{}{map[string]string{"headBlockHash": headHash, "safeBlockHash": headHash, "finalizedBlockHash": headHash}, map[string]interface{}{"timestamp": "0xffffffff", "prevRandao": zeroHash, "suggestedFeeRecipient": cfg.From}},
+			},
+			Desc:         "engine_forkchoiceUpdatedV1 after Shanghai (unsupported fork)",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38005,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "engine_newPayloadV3",
+				Params:  []interface{}{map[string]string{"parentHash": unknownBlockHash}, []interface{}{}, zeroHash},
+			},
+			Desc:         "engine_newPayloadV3 malformed payload",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32602,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "engine_newPayloadV3",
+				Params:  []interface{}{},
+			},
+			Desc:         "engine_newPayloadV3 missing params",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32602,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "engine_getPayloadBodiesByRangeV1",
+				Params:  []interface{}{"0x1", "0x401"},
+			},
+			Desc:         "engine_getPayloadBodiesByRangeV1 too large request (1025 bodies)",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38004,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "engine_getPayloadBodiesByHashV1",
+				Params:  []interface{}{manyHashes},
+			},
+			Desc:         "engine_getPayloadBodiesByHashV1 too large request (1025 hashes)",
+			Headers:      tokens["valid"],
+			ExpectedCode: -38004,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "engine_getPayloadBodiesByRangeV1",
+				Params:  []interface{}{"0x0", "0x1"},
+			},
+			Desc:         "engine_getPayloadBodiesByRangeV1 start at 0",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32602,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "engine_getClientVersionV1",
+				Params:  []interface{}{map[string]string{"code": "EE", "name": "eth-err-tests", "version": "v0.0.0", "commit": "0x00000000"}},
+			},
+			Desc:    "engine_getClientVersionV1 proper request",
+			Headers: tokens["valid"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "engine_exchangeTransitionConfigurationV1",
+				Params:  []interface{}{map[string]string{"terminalTotalDifficulty": "0x0", "terminalBlockHash": zeroHash, "terminalBlockNumber": "0x0"}},
+			},
+			Desc:    "engine_exchangeTransitionConfigurationV1 deprecated method",
+			Headers: tokens["valid"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "eth_chainId",
+				Params:  []interface{}{},
+			},
+			Desc:    "eth_chainId on the authenticated port",
+			Headers: tokens["valid"],
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "engine_wrongMethod",
+				Params:  []interface{}{},
+			},
+			Desc:         "Incorrect method name",
+			Headers:      tokens["valid"],
+			ExpectedCode: -32601,
+		},
+	}
+
+	// Without a head block the fork choice scenarios would send an empty head hash
+	if err != nil {
+		fmt.Printf("Warning: skipping the %s scenarios on the head block hash: %v\n", t.Name(), err)
+		return withoutIDs(requests, 11, 12, 13, 15)
+	}
+	return requests
+}
+
+func (t *EngineTestCase) Execute(cfg config.Config) {
+	if cfg.EngineUrl == "" || cfg.JWTSecret == "" {
+		fmt.Printf("Skipping: no Engine API endpoint available on %s\n", cfg.Network)
+		return
+	}
+	// Without a listener every scenario would fail on the transport instead of asserting its error code
+	if err := probeEndpoint(cfg.EngineUrl); err != nil {
+		fmt.Printf("Skipping: the Engine API endpoint of %s is not reachable: %v\n", cfg.Network, err)
+		return
+	}
+
+	requests := t.GetRequests(cfg)
+	engineCfg := cfg
+	engineCfg.Url = cfg.EngineUrl
+	jsonrpc.SendReq(requests, engineCfg)
+}
+
+// probeEndpoint checks that something accepts connections on the host and port of url.
+func probeEndpoint(url string) error {
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("tcp", parsed.Host, 2*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// engineTokens returns the Authorization headers used by the scenarios, keyed by variant.
+// Clients must reject tokens whose iat is more than 60 seconds away from the current time.
+func engineTokens(cfg config.Config) map[string]map[string]string {
+	bearer := func(secret string, issuedAt time.Time) map[string]string {
+		token, err := jsonrpc.NewEngineJWT(secret, issuedAt)
+		if err != nil {
+			return map[string]string{}
+		}
+		return map[string]string{"Authorization": "Bearer " + token}
+	}
+
+	now := time.Now()
+	return map[string]map[string]string{
+		"valid":       bearer(cfg.JWTSecret, now),
+		"expired":     bearer(cfg.JWTSecret, now.Add(-2*time.Minute)),
+		"future":      bearer(cfg.JWTSecret, now.Add(2*time.Minute)),
+		"wrongSecret": bearer(wrongJWTSecret, now),
+		"malformed":   {"Authorization": "Bearer not-a-jwt"},
+	}
+}
+
+func NewEngineTestCase() pkgTypes.TestCase {
+	return &EngineTestCase{}
+}
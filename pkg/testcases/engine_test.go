@@ -0,0 +1,29 @@
+package testcases
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+)
+
+func TestEngineSkipsUnreachableEndpoint(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	engineUrl := "http://" + listener.Addr().String()
+	listener.Close()
+
+	cfg := config.Config{Network: "test", Url: engineUrl, EngineUrl: engineUrl, JWTSecret: wrongJWTSecret}
+	output := captureStdout(t, func() { NewEngineTestCase().Execute(cfg) })
+	if !strings.HasPrefix(output, "Skipping: the Engine API endpoint of test is not reachable") || strings.Contains(output, "Scenario:") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+
+	output = captureStdout(t, func() { NewEngineTestCase().Execute(config.Config{Network: "geth-local", Url: engineUrl}) })
+	if output != "Skipping: no Engine API endpoint available on geth-local\n" {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+}
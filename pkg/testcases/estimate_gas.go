@@ -17,7 +17,7 @@ func (t *EstimateGasTestCase) RequiresContract() bool {
 }
 
 func (t *EstimateGasTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
-	return []pkgTypes.Meta{
+	requests := []pkgTypes.Meta{
 		{
 			JsonRpcRequest: pkgTypes.JsonRpcRequest{
 				JsonRpc: "2.0",
@@ -114,6 +114,17 @@ func (t *EstimateGasTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
 			Desc: "Missing from field",
 		},
 	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_estimateGas", 100, func(block interface{}) []interface{} {
+		return []interface{}{
+			map[string]string{
+				"from": cfg.From,
+				"to":   cfg.ToContract,
+				"data": "0x6057361d0002c6c8f4b6852b7fe72b4cbf8d304a8b4f8b1eec216b77e1284cc4",
+			},
+			block,
+		}
+	})...)
 }
 
 func (t *EstimateGasTestCase) Execute(cfg config.Config) {
@@ -0,0 +1,207 @@
+package testcases
+
+import (
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// FeeMarketTestCase covers eth_feeHistory, eth_gasPrice, eth_maxPriorityFeePerGas and eth_blobBaseFee.
+type FeeMarketTestCase struct{}
+
+func (t *FeeMarketTestCase) Name() string {
+	return "eth_feeHistory"
+}
+
+func (t *FeeMarketTestCase) RequiresContract() bool {
+	return false
+}
+
+func (t *FeeMarketTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest", []interface{}{25, 50, 75}},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x0", "latest", []interface{}{}},
+			},
+			Desc: "Zero block count",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x10000", "latest", []interface{}{}},
+			},
+			Desc: "Too many blocks (65536)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{4, "latest", []interface{}{}},
+			},
+			Desc: "Block count as integer instead of hex",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest", []interface{}{-1, 50}},
+			},
+			Desc: "Reward percentile below 0",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest", []interface{}{50, 101}},
+			},
+			Desc: "Reward percentile above 100",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest", []interface{}{75, 25}},
+			},
+			Desc: "Reward percentiles not monotonically increasing",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest", []interface{}{50, 50}},
+			},
+			Desc: "Duplicate reward percentiles",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest", []interface{}{"50"}},
+			},
+			Desc: "Reward percentile as string",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "0xffffffff", []interface{}{}},
+			},
+			Desc: "Newest block in the future",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "unsupported", []interface{}{}},
+			},
+			Desc: "Invalid newest block tag",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4", "latest"},
+			},
+			Desc: "Missing reward percentiles",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_feeHistory",
+				Params:  []interface{}{"0x4"},
+			},
+			Desc: "Missing newest block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_gasPrice",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_gasPrice proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_gasPrice",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_gasPrice unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_maxPriorityFeePerGas",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_maxPriorityFeePerGas proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_maxPriorityFeePerGas",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_maxPriorityFeePerGas unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_blobBaseFee",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_blobBaseFee proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_blobBaseFee",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_blobBaseFee unexpected param",
+		},
+	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_feeHistory", 100, func(block interface{}) []interface{} {
+		return []interface{}{"0x4", block, []interface{}{}}
+	})...)
+}
+
+func (t *FeeMarketTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewFeeMarketTestCase() pkgTypes.TestCase {
+	return &FeeMarketTestCase{}
+}
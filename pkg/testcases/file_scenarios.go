@@ -0,0 +1,407 @@
+package testcases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioFile is the on-disk format of a declarative test case. YAML and JSON files share the format:
+//
+//	name: eth_getCode_file
+//	requiresContract: true
+//	scenarios:
+//	  - desc: Proper request
+//	    method: eth_getCode
+//	    params: ["{{.ToContract}}", "latest"]
+//	  - desc: Unsupported block parameter
+//	    method: eth_getCode
+//	    params: ["{{.ToContract}}", "unsupported"]
+//	    expectedError: {code: -32602, message: "invalid"}
+//
+// A scenario with a tx block sends a Storage.store(20) transaction built like the eth_sendRawTransaction
+// test case instead of params, with library modifiers applied by name:
+//
+//	scenarios:
+//	  - desc: NONCE_TOO_HIGH
+//	    method: eth_sendRawTransaction
+//	    tx:
+//	      modifiers:
+//	        - {name: NonceModifier, args: ["+100"]}
+//	      preSend: {name: batchTx, args: [1000]}
+//	      batch: true
+//
+// String params and args are Go templates rendered against ScenarioData. Hex values must be quoted in YAML,
+// otherwise they are decoded as integers.
+type ScenarioFile struct {
+	Name             string         `yaml:"name"`
+	RequiresContract bool           `yaml:"requiresContract"`
+	Scenarios        []FileScenario `yaml:"scenarios"`
+}
+
+type FileScenario struct {
+	Id            int            `yaml:"id"` // Defaults to the scenario's position in the file, starting at 1
+	Desc          string         `yaml:"desc"`
+	Method        string         `yaml:"method"`
+	Params        []interface{}  `yaml:"params"`
+	ExpectedError *ExpectedError `yaml:"expectedError"`
+	Tx            *FileTx        `yaml:"tx"`
+}
+
+type FileTx struct {
+	Modifiers []NamedStep `yaml:"modifiers"` // Applied in order, see jsonrpc.ModifierNames
+	PreSend   *NamedStep  `yaml:"preSend"`   // One of namedPreSends
+	Batch     bool        `yaml:"batch"`     // Send the pre-send tx in the same batch as the scenario tx
+}
+
+// NamedStep refers to a modifier or pre-send step by name, with its arguments.
+type NamedStep struct {
+	Name string        `yaml:"name"`
+	Args []interface{} `yaml:"args"`
+}
+
+type ExpectedError struct {
+	Code    int    `yaml:"code"`
+	Message string `yaml:"message"` // Substring of the error message
+}
+
+// ScenarioData is the data available to param templates, e.g. {{.From}} or {{.Contracts.opcodes}}.
+type ScenarioData struct {
+	From            string
+	ToContract      string
+	InvalidContract string
+	DevAccount      string
+	ChainID         int64
+	Contracts       map[string]string // contract name -> deployed address
+}
+
+func NewScenarioData(cfg config.Config) ScenarioData {
+	contracts := make(map[string]string, len(cfg.DeployedContracts))
+	for name, address := range cfg.DeployedContracts {
+		contracts[name] = address.Hex()
+	}
+
+	return ScenarioData{
+		From:            cfg.From,
+		ToContract:      cfg.ToContract,
+		InvalidContract: cfg.InvalidContract,
+		DevAccount:      cfg.DevAccount,
+		ChainID:         cfg.ChainID,
+		Contracts:       contracts,
+	}
+}
+
+// FileTestCase is a TestCase loaded from a ScenarioFile.
+type FileTestCase struct {
+	file ScenarioFile
+	path string
+}
+
+func (t *FileTestCase) Name() string {
+	return t.file.Name
+}
+
+func (t *FileTestCase) RequiresContract() bool {
+	return t.file.RequiresContract
+}
+
+func (t *FileTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	data := NewScenarioData(cfg)
+
+	requests := make([]pkgTypes.Meta, 0, len(t.file.Scenarios))
+	for i, scenario := range t.file.Scenarios {
+		params, err := renderParams(scenario.Params, data)
+		if err != nil {
+			fmt.Printf("Warning: %s scenario %q: %v\n", t.path, scenario.Desc, err)
+		}
+
+		request := pkgTypes.Meta{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      scenario.id(i),
+				Method:  scenario.Method,
+				Params:  params,
+			},
+			Desc: scenario.Desc,
+		}
+		if scenario.ExpectedError != nil {
+			request.ExpectedCode = scenario.ExpectedError.Code
+			request.ExpectedMessage = scenario.ExpectedError.Message
+		}
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
+func (t *FileTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	data := NewScenarioData(cfg)
+
+	var client *ethclient.Client
+	defer func() {
+		if client != nil {
+			client.Close()
+		}
+	}()
+
+	for i, scenario := range t.file.Scenarios {
+		if scenario.Tx == nil {
+			jsonrpc.SendReq(requests[i:i+1], cfg)
+			continue
+		}
+
+		txScenario, err := scenario.toScenario(cfg, data, i)
+		if err != nil {
+			fmt.Printf("Error building scenario %d (%s): %v\n", scenario.id(i), scenario.Desc, err)
+			continue
+		}
+
+		if client == nil {
+			client, err = ethclient.Dial(cfg.Url)
+			if err != nil {
+				fmt.Println("Error connecting to Ethereum client:", err)
+				return
+			}
+		}
+
+		if err := jsonrpc.SendTransaction(context.Background(), client, cfg, txScenario); err != nil {
+			fmt.Printf("Error executing scenario %d (%s): %v\n", txScenario.ID, txScenario.Desc, err)
+		}
+		fmt.Println()
+	}
+}
+
+func (s FileScenario) id(index int) int {
+	if s.Id != 0 {
+		return s.Id
+	}
+	return index + 1
+}
+
+// toScenario resolves the tx block into a transaction scenario for jsonrpc.SendTransaction.
+func (s FileScenario) toScenario(cfg config.Config, data ScenarioData, index int) (pkgTypes.Scenario, error) {
+	scenario := pkgTypes.Scenario{
+		ID:       s.id(index),
+		Desc:     s.Desc,
+		Method:   s.Method,
+		UseBatch: s.Tx.Batch,
+	}
+
+	for _, step := range s.Tx.Modifiers {
+		args, err := renderParams(step.Args, data)
+		if err != nil {
+			return scenario, fmt.Errorf("%s: %w", step.Name, err)
+		}
+		modifier, err := jsonrpc.ModifierByName(cfg, step.Name, args)
+		if err != nil {
+			return scenario, err
+		}
+		scenario.Modifiers = append(scenario.Modifiers, modifier)
+	}
+
+	if s.Tx.PreSend != nil {
+		args, err := renderParams(s.Tx.PreSend.Args, data)
+		if err != nil {
+			return scenario, fmt.Errorf("%s: %w", s.Tx.PreSend.Name, err)
+		}
+		preSend, err := preSendByName(s.Tx.PreSend.Name, args)
+		if err != nil {
+			return scenario, err
+		}
+		scenario.PreSend = preSend
+	}
+
+	return scenario, nil
+}
+
+// namedPreSends exposes the pre-send steps of the eth_sendRawTransaction test case by name.
+var namedPreSends = map[string]func(args []interface{}) (pkgTypes.PreSendFunc, error){
+	// batchTx signs a copy of the scenario tx, with its value replaced by the optional first argument
+	"batchTx": func(args []interface{}) (pkgTypes.PreSendFunc, error) {
+		if len(args) == 0 {
+			return createBatchTx(nil), nil
+		}
+		value, ok := new(big.Int).SetString(fmt.Sprint(args[0]), 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v", args[0])
+		}
+		return createBatchTx(value), nil
+	},
+	// estimateGas calls eth_estimateGas with the scenario tx and reports the response as a pre-send error
+	"estimateGas": func(args []interface{}) (pkgTypes.PreSendFunc, error) {
+		return ethEstimateGasPresend, nil
+	},
+}
+
+func preSendByName(name string, args []interface{}) (pkgTypes.PreSendFunc, error) {
+	factory, ok := namedPreSends[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preSend %q, available: batchTx, estimateGas", name)
+	}
+
+	preSend, err := factory(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return preSend, nil
+}
+
+// LoadScenarioFiles turns every .yaml, .yml and .json file in dir into a TestCase, in file name order.
+func LoadScenarioFiles(dir string) ([]pkgTypes.TestCase, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario dir: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	testCases := make([]pkgTypes.TestCase, 0, len(paths))
+	names := make(map[string]string)
+	for _, path := range paths {
+		testCase, err := LoadScenarioFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := names[testCase.Name()]; ok {
+			return nil, fmt.Errorf("%s: test case name %q already used by %s", path, testCase.Name(), other)
+		}
+		names[testCase.Name()] = path
+		testCases = append(testCases, testCase)
+	}
+
+	return testCases, nil
+}
+
+// LoadScenarioFile parses and validates a single scenario file.
+func LoadScenarioFile(path string) (*FileTestCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+
+	var file ScenarioFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := validateScenarioFile(file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &FileTestCase{file: file, path: path}, nil
+}
+
+func validateScenarioFile(file ScenarioFile) error {
+	if file.Name == "" {
+		return errors.New("missing name")
+	}
+	if len(file.Scenarios) == 0 {
+		return errors.New("no scenarios")
+	}
+
+	for i, scenario := range file.Scenarios {
+		if scenario.Desc == "" || scenario.Method == "" {
+			return fmt.Errorf("scenario %d: desc and method are required", i+1)
+		}
+		// Render against empty data so unknown template fields are reported at load time
+		if _, err := renderParams(scenario.Params, ScenarioData{}); err != nil {
+			return fmt.Errorf("scenario %q: %w", scenario.Desc, err)
+		}
+		if scenario.Tx == nil {
+			continue
+		}
+		if len(scenario.Params) > 0 {
+			return fmt.Errorf("scenario %q: params and tx are mutually exclusive", scenario.Desc)
+		}
+		if scenario.ExpectedError != nil {
+			return fmt.Errorf("scenario %q: expectedError is not supported for tx scenarios", scenario.Desc)
+		}
+		if scenario.Tx.Batch && scenario.Tx.PreSend == nil {
+			return fmt.Errorf("scenario %q: batch requires a preSend step", scenario.Desc)
+		}
+		if _, err := scenario.toScenario(config.Config{}, ScenarioData{}, i); err != nil {
+			return fmt.Errorf("scenario %q: %w", scenario.Desc, err)
+		}
+	}
+
+	return nil
+}
+
+// renderParams executes the templates in every string of params, including nested arrays and objects.
+// On error the unrendered params are returned along with the error.
+func renderParams(params []interface{}, data ScenarioData) ([]interface{}, error) {
+	if params == nil {
+		return []interface{}{}, nil
+	}
+
+	rendered, err := renderValue(params, data)
+	if err != nil {
+		return params, err
+	}
+	return rendered.([]interface{}), nil
+}
+
+func renderValue(value interface{}, data ScenarioData) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		tmpl, err := template.New("param").Option("missingkey=zero").Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, err
+		}
+		return out.String(), nil
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, item := range v {
+			r, err := renderValue(item, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = r
+		}
+		return rendered, nil
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			r, err := renderValue(item, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = r
+		}
+		return rendered, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,246 @@
+package testcases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/mockrpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func writeScenarioFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadScenarioFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFile(t, dir, "b.yaml", `
+name: yaml_case
+requiresContract: true
+scenarios:
+  - desc: Templated params
+    method: eth_call
+    params: [{"from": "{{.From}}", "to": "{{.Contracts.opcodes}}"}, "latest"]
+  - id: 42
+    desc: Expected error
+    method: eth_getCode
+    params: ["{{.ToContract}}", "0x10"]
+    expectedError: {code: -32602, message: "invalid"}
+`)
+	writeScenarioFile(t, dir, "a.json", `{"name": "json_case", "scenarios": [{"desc": "No params", "method": "eth_chainId"}]}`)
+	writeScenarioFile(t, dir, "notes.txt", "ignored")
+
+	testCases, err := LoadScenarioFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(testCases) != 2 || testCases[0].Name() != "json_case" || testCases[1].Name() != "yaml_case" {
+		t.Fatalf("unexpected test cases: %v", testCases)
+	}
+	if testCases[0].RequiresContract() || !testCases[1].RequiresContract() {
+		t.Error("requiresContract not loaded")
+	}
+
+	cfg := config.Config{
+		From:       "0x00000000000000000000000000000000000000f0",
+		ToContract: "0x00000000000000000000000000000000000000aa",
+		DeployedContracts: map[string]common.Address{
+			"opcodes": common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+		},
+	}
+
+	requests := testCases[1].GetRequests(cfg)
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	first := requests[0]
+	if first.Id != 1 || first.Method != "eth_call" || first.Desc != "Templated params" {
+		t.Errorf("unexpected first request: %+v", first)
+	}
+	wantParams := []interface{}{
+		map[string]interface{}{"from": cfg.From, "to": cfg.DeployedContracts["opcodes"].Hex()},
+		"latest",
+	}
+	if !reflect.DeepEqual(first.Params, wantParams) {
+		t.Errorf("params = %#v, want %#v", first.Params, wantParams)
+	}
+
+	second := requests[1]
+	if second.Id != 42 || second.ExpectedCode != -32602 || second.ExpectedMessage != "invalid" {
+		t.Errorf("unexpected second request: %+v", second)
+	}
+	if !reflect.DeepEqual(second.Params, []interface{}{cfg.ToContract, "0x10"}) {
+		t.Errorf("unexpected params: %#v", second.Params)
+	}
+
+	if params := testCases[0].GetRequests(cfg)[0].Params; params == nil || len(params) != 0 {
+		t.Errorf("missing params should be sent as an empty array, got %#v", params)
+	}
+}
+
+func TestLoadScenarioFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing name", `scenarios: [{desc: a, method: eth_chainId}]`, "missing name"},
+		{"no scenarios", `name: empty`, "no scenarios"},
+		{"missing method", `{name: x, scenarios: [{desc: a}]}`, "desc and method are required"},
+		{"unknown field", `{name: x, scenarios: [{desc: a, method: m, param: []}]}`, "field param not found"},
+		{"unknown template field", `{name: x, scenarios: [{desc: a, method: m, params: ["{{.Sender}}"]}]}`, "Sender"},
+		{"template syntax", `{name: x, scenarios: [{desc: a, method: m, params: ["{{.From"]}]}`, "unclosed action"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeScenarioFile(t, dir, "case.yaml", tt.content)
+			_, err := LoadScenarioFile(filepath.Join(dir, "case.yaml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadScenarioFilesDuplicateName(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFile(t, dir, "a.yaml", `{name: dup, scenarios: [{desc: a, method: m}]}`)
+	writeScenarioFile(t, dir, "b.yaml", `{name: dup, scenarios: [{desc: b, method: m}]}`)
+
+	if _, err := LoadScenarioFiles(dir); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("expected a duplicate name error, got %v", err)
+	}
+}
+
+func TestExampleScenarioFiles(t *testing.T) {
+	testCases, err := LoadScenarioFiles(filepath.Join("..", "..", "scenarios"))
+	if err != nil {
+		t.Fatalf("example scenario files failed to load: %v", err)
+	}
+	if len(testCases) == 0 {
+		t.Error("expected example scenario files")
+	}
+}
+
+func TestFileTxScenarios(t *testing.T) {
+	server := mockrpc.NewServer(mockrpc.Profile{})
+	server.HandleDevChain(1337)
+	url, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeScenarioFile(t, dir, "tx.yaml", `
+name: tx_case
+scenarios:
+  - desc: Chain id
+    method: eth_chainId
+  - desc: NONCE_TOO_HIGH
+    method: eth_sendRawTransaction
+    tx:
+      modifiers:
+        - {name: NonceModifier, args: ["+100"]}
+        - {name: ToAddressModifier, args: ["{{.InvalidContract}}"]}
+  - desc: ALREADY_KNOWN
+    method: eth_sendRawTransaction
+    tx:
+      preSend: {name: batchTx}
+      batch: true
+`)
+	testCases, err := LoadScenarioFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Config{
+		Url:             url,
+		PrivateKey:      common.Bytes2Hex(crypto.FromECDSA(key)),
+		ChainID:         1337,
+		ToContract:      "0x00000000000000000000000000000000000000aa",
+		InvalidContract: "0x00000000000000000000000000000000000000bb",
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	testCases[0].Execute(cfg)
+	os.Stdout = stdout
+
+	var methods []string
+	var txs []*types.Transaction
+	for _, req := range server.Requests() {
+		switch req.Method {
+		case "eth_chainId":
+			methods = append(methods, req.Method)
+		case "eth_sendRawTransaction":
+			methods = append(methods, req.Method)
+			var params []hexutil.Bytes
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				t.Fatal(err)
+			}
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalBinary(params[0]); err != nil {
+				t.Fatal(err)
+			}
+			txs = append(txs, tx)
+		}
+	}
+
+	if !reflect.DeepEqual(methods, []string{"eth_chainId", "eth_sendRawTransaction", "eth_sendRawTransaction", "eth_sendRawTransaction"}) {
+		t.Fatalf("unexpected request order: %v", methods)
+	}
+	if txs[0].Nonce() != 100 || *txs[0].To() != common.HexToAddress(cfg.InvalidContract) {
+		t.Errorf("modifiers not applied: nonce %d, to %s", txs[0].Nonce(), txs[0].To())
+	}
+	if txs[1].Hash() != txs[2].Hash() {
+		t.Error("expected the batched pre-send tx to be identical to the scenario tx")
+	}
+}
+
+func TestLoadScenarioFileTxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tx      string
+		wantErr string
+	}{
+		{"unknown modifier", `{modifiers: [{name: NonceModifer, args: [1]}]}`, "unknown modifier"},
+		{"bad args", `{modifiers: [{name: NonceModifier, args: [abc]}]}`, "invalid number"},
+		{"unknown preSend", `{preSend: {name: replace}}`, "unknown preSend"},
+		{"batch without preSend", `{batch: true}`, "batch requires a preSend step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeScenarioFile(t, dir, "case.yaml", "{name: x, scenarios: [{desc: a, method: eth_sendRawTransaction, tx: "+tt.tx+"}]}")
+			_, err := LoadScenarioFile(filepath.Join(dir, "case.yaml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
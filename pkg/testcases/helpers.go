@@ -0,0 +1,118 @@
+package testcases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/contract"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var errNoResult = errors.New("response has no result")
+
+// inputHex ABI-encodes a call to one of the bundled contracts and returns it as 0x-prefixed hex.
+// The ABIs are embedded, so a failure here is a programming error.
+func inputHex(name contract.Name, method string, args ...interface{}) string {
+	input, err := contract.BuildInput(name, method, args...)
+	if err != nil {
+		panic(fmt.Sprintf("error building input for %s.%s: %v", name, method, err))
+	}
+	return hexutil.Encode(input)
+}
+
+// withoutIDs returns requests without the scenarios with the given ids. The other scenarios keep their ids so
+// they line up with the reports of complete runs.
+func withoutIDs(requests []pkgTypes.Meta, ids ...int) []pkgTypes.Meta {
+	kept := make([]pkgTypes.Meta, 0, len(requests))
+	for _, request := range requests {
+		if !slices.Contains(ids, request.Id) {
+			kept = append(kept, request)
+		}
+	}
+	return kept
+}
+
+// rpcResult sends a single request and decodes its result into out.
+func rpcResult(cfg config.Config, method string, params []interface{}, out interface{}) error {
+	request := pkgTypes.JsonRpcRequest{
+		JsonRpc: "2.0",
+		Id:      1,
+		Method:  method,
+		Params:  params,
+	}
+
+	response, err := jsonrpc.SendRawJSONRPCRequest(cfg.Url, []pkgTypes.JsonRpcRequest{request})
+	if err != nil {
+		return err
+	}
+
+	var batchResult []struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(response), &batchResult); err != nil {
+		return err
+	}
+	if len(batchResult) == 0 || len(batchResult[0].Result) == 0 || string(batchResult[0].Result) == "null" {
+		return errNoResult
+	}
+
+	return json.Unmarshal(batchResult[0].Result, out)
+}
+
+// sendTx submits a Storage.store(20) transaction with the given modifiers applied, without waiting for it
+// to be mined. The returned client is still open so callers can follow up on the transaction.
+func sendTx(cfg config.Config, modifiers ...pkgTypes.Modifier) (*ethclient.Client, *types.Transaction, error) {
+	ctx := context.Background()
+
+	privateKey, err := crypto.HexToECDSA(cfg.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading private key: %w", err)
+	}
+
+	input, err := contract.BuildInput(contract.Storage, "store", new(big.Int).SetUint64(20))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building input: %w", err)
+	}
+
+	client, err := ethclient.Dial(cfg.Url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to Ethereum client: %w", err)
+	}
+
+	params, err := jsonrpc.NewTxParamsFromDefaults(ctx, client, cfg, privateKey, common.HexToAddress(cfg.ToContract), input)
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("error building tx params: %w", err)
+	}
+
+	for _, modifier := range modifiers {
+		if err := modifier(ctx, client, params); err != nil {
+			client.Close()
+			return nil, nil, fmt.Errorf("error applying modifier: %w", err)
+		}
+	}
+
+	signedTx, err := jsonrpc.SignTransaction(jsonrpc.BuildTransaction(params), params)
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("error signing transaction: %w", err)
+	}
+
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("error sending transaction: %w", err)
+	}
+
+	return client, signedTx, nil
+}
@@ -0,0 +1,404 @@
+package testcases
+
+import (
+	"fmt"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// unknownFilterID is a well-formed filter id that was never installed
+const unknownFilterID = "0xdeadbeefdeadbeefdeadbeefdeadbeef"
+
+// logRangeLimit is the smallest default eth_getLogs block range limit of the clients (besu's --rpc-max-logs-range)
+const logRangeLimit = 5000
+
+// storedNumberTopic is topic0 of the Storage contract's storedNumber event
+var storedNumberTopic = crypto.Keccak256Hash([]byte("storedNumber(address,uint256,uint256)")).Hex()
+
+// LogsTestCase covers eth_getLogs and the filter API (eth_newFilter, eth_getFilterChanges,
+// eth_getFilterLogs and eth_uninstallFilter).
+type LogsTestCase struct{}
+
+func (t *LogsTestCase) Name() string {
+	return "eth_getLogs"
+}
+
+func (t *LogsTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *LogsTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"fromBlock": "earliest",
+						"toBlock":   "latest",
+						"address":   cfg.ToContract,
+						"topics":    []interface{}{storedNumberTopic},
+					},
+				},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"fromBlock": "0x2",
+						"toBlock":   "0x1",
+						"address":   cfg.ToContract,
+					},
+				},
+			},
+			Desc: "fromBlock greater than toBlock",
+		},
+	}
+
+	// The range scenario needs more blocks than the client limit below the head
+	if head, err := headNumber(cfg); err != nil {
+		fmt.Printf("Warning: skipping the eth_getLogs block range scenario: %v\n", err)
+	} else if head < logRangeLimit {
+		fmt.Printf("Warning: skipping the eth_getLogs block range scenario: the chain has %d blocks, the limit is %d\n", head+1, logRangeLimit)
+	} else {
+		requests = append(requests, pkgTypes.Meta{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"fromBlock": hexutil.EncodeUint64(head - logRangeLimit),
+						"toBlock":   hexutil.EncodeUint64(head),
+						"address":   cfg.ToContract,
+					},
+				},
+			},
+			Desc: fmt.Sprintf("Block range too large (%d blocks up to the head)", logRangeLimit+1),
+		})
+	}
+
+	return append(requests, []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockHash": unknownBlockHash,
+						"fromBlock": "0x1",
+						"toBlock":   "latest",
+					},
+				},
+			},
+			Desc: "blockHash combined with fromBlock/toBlock",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockHash": unknownBlockHash,
+					},
+				},
+			},
+			Desc: "Unknown blockHash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"address": cfg.ToContract,
+						"topics":  []interface{}{[]interface{}{[]interface{}{storedNumberTopic}}},
+					},
+				},
+			},
+			Desc: "Invalid topics nesting (3 levels)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"address": cfg.ToContract,
+						"topics": []interface{}{
+							storedNumberTopic, nil, nil, nil, storedNumberTopic,
+						},
+					},
+				},
+			},
+			Desc: "Too many topics (5 positions)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"address": cfg.ToContract,
+						"topics":  []interface{}{"0x1234"},
+					},
+				},
+			},
+			Desc: "Topic not 32 bytes",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"address": "0x1234",
+					},
+				},
+			},
+			Desc: "Invalid address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_getLogs",
+				Params: []interface{}{
+					map[string]interface{}{
+						"address": []interface{}{cfg.ToContract, "0x1234"},
+					},
+				},
+			},
+			Desc: "Invalid address in address list",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_getLogs",
+				Params:  []interface{}{},
+			},
+			Desc: "Missing filter object",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_getLogs",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "Invalid params types",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_newFilter",
+				Params: []interface{}{
+					map[string]interface{}{
+						"fromBlock": "0x2",
+						"toBlock":   "0x1",
+						"address":   cfg.ToContract,
+					},
+				},
+			},
+			Desc: "eth_newFilter fromBlock greater than toBlock",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_newFilter",
+				Params: []interface{}{
+					map[string]interface{}{
+						"address": cfg.ToContract,
+						"topics":  []interface{}{[]interface{}{[]interface{}{storedNumberTopic}}},
+					},
+				},
+			},
+			Desc: "eth_newFilter invalid topics nesting",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_newFilter",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockHash": unknownBlockHash,
+					},
+				},
+			},
+			Desc: "eth_newFilter with blockHash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_getFilterChanges",
+				Params:  []interface{}{unknownFilterID},
+			},
+			Desc: "eth_getFilterChanges unknown filter id",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_getFilterLogs",
+				Params:  []interface{}{unknownFilterID},
+			},
+			Desc: "eth_getFilterLogs unknown filter id",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_uninstallFilter",
+				Params:  []interface{}{unknownFilterID},
+			},
+			Desc: "eth_uninstallFilter unknown filter id",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_getFilterChanges",
+				Params:  []interface{}{12345},
+			},
+			Desc: "eth_getFilterChanges non-string filter id",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_getFilterChanges",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_getFilterChanges missing filter id",
+		},
+	}...)
+}
+
+// filterLifecycleRequests exercises filters that were installed by Execute. Uninstalled filters stand in
+// for expired ones, since waiting out the client's filter timeout (5 minutes on geth) is impractical.
+func (t *LogsTestCase) filterLifecycleRequests(logFilterID, blockFilterID string) []pkgTypes.Meta {
+	return []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      50,
+				Method:  "eth_getFilterChanges",
+				Params:  []interface{}{logFilterID},
+			},
+			Desc: "eth_getFilterChanges installed log filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      51,
+				Method:  "eth_getFilterLogs",
+				Params:  []interface{}{logFilterID},
+			},
+			Desc: "eth_getFilterLogs installed log filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      52,
+				Method:  "eth_getFilterLogs",
+				Params:  []interface{}{blockFilterID},
+			},
+			Desc: "eth_getFilterLogs on a block filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      53,
+				Method:  "eth_uninstallFilter",
+				Params:  []interface{}{logFilterID},
+			},
+			Desc: "eth_uninstallFilter installed log filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      54,
+				Method:  "eth_uninstallFilter",
+				Params:  []interface{}{logFilterID},
+			},
+			Desc: "eth_uninstallFilter already uninstalled filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      55,
+				Method:  "eth_getFilterChanges",
+				Params:  []interface{}{logFilterID},
+			},
+			Desc: "eth_getFilterChanges expired (uninstalled) filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      56,
+				Method:  "eth_getFilterLogs",
+				Params:  []interface{}{logFilterID},
+			},
+			Desc: "eth_getFilterLogs expired (uninstalled) filter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      57,
+				Method:  "eth_uninstallFilter",
+				Params:  []interface{}{blockFilterID},
+			},
+			Desc: "eth_uninstallFilter installed block filter",
+		},
+	}
+}
+
+func (t *LogsTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+
+	var logFilterID string
+	err := rpcResult(cfg, "eth_newFilter", []interface{}{map[string]interface{}{
+		"fromBlock": "earliest",
+		"address":   cfg.ToContract,
+		"topics":    []interface{}{storedNumberTopic},
+	}}, &logFilterID)
+	if err != nil {
+		fmt.Println("Error installing log filter:", err)
+		return
+	}
+
+	var blockFilterID string
+	if err := rpcResult(cfg, "eth_newBlockFilter", []interface{}{}, &blockFilterID); err != nil {
+		fmt.Println("Error installing block filter:", err)
+		return
+	}
+
+	jsonrpc.SendReq(t.filterLifecycleRequests(logFilterID, blockFilterID), cfg)
+}
+
+func NewLogsTestCase() pkgTypes.TestCase {
+	return &LogsTestCase{}
+}
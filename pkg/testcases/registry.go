@@ -1,31 +1,71 @@
 package testcases
 
 import (
+	"sort"
+
 	pkgTypes "github.com/eth-error-tests/pkg/types"
 )
 
+// registeredTestCases holds test cases added at runtime, e.g. loaded from scenario files
+var registeredTestCases []pkgTypes.TestCase
+
+// RegisterTestCases makes test cases available by name and adds them to a "Scenario File Tests" suite.
+// A registered test case with the same name as a built-in one takes precedence.
+func RegisterTestCases(testCases ...pkgTypes.TestCase) {
+	registeredTestCases = append(registeredTestCases, testCases...)
+}
+
 func GetAllTestSuites() []pkgTypes.TestSuite {
-	return []pkgTypes.TestSuite{
-		/* {
+	suites := []pkgTypes.TestSuite{
+		{
 			Name:              "Basic RPC Tests",
 			Description:       "Tests for basic Ethereum RPC methods without contract requirements",
 			RequiresContracts: false,
 			TestCases: []pkgTypes.TestCase{
+				NewBasicTestCase(),
 				NewBalanceTestCase(),
+				NewBlockTestCase(),
+				NewFeeMarketTestCase(),
 			},
-		}, */
+		},
 		{
 			Name:              "Contract Interaction Tests",
-			Description:       "",
+			Description:       "Tests for calls, state reads and transactions against the deployed contracts",
 			RequiresContracts: true,
 			TestCases: []pkgTypes.TestCase{
-				// NewCodeAtTestCase(),
-				// NewCallTestCase(),
+				NewCodeAtTestCase(),
+				NewCallTestCase(),
 				NewEstimateGasTestCase(),
 				NewSendTransactionTestCase(),
+				NewSimulateTestCase(),
+				NewLogsTestCase(),
+				NewTransactionLookupTestCase(),
+				NewStateTestCase(),
+				NewAccessListTestCase(),
+			},
+		},
+		{
+			Name:              "Node Namespace Tests",
+			Description:       "Tests for the debug, txpool, signing and Engine API methods, which public endpoints usually do not expose",
+			RequiresContracts: true,
+			TestCases: []pkgTypes.TestCase{
+				NewDebugTraceTestCase(),
+				NewTxPoolTestCase(),
+				NewSigningTestCase(),
+				NewEngineTestCase(),
 			},
 		},
 	}
+
+	if len(registeredTestCases) > 0 {
+		suites = append(suites, pkgTypes.TestSuite{
+			Name:        "Scenario File Tests",
+			Description: "Test cases loaded from declarative scenario files",
+			TestCases:   registeredTestCases,
+		})
+	}
+
+	return suites
 }
 
 func GetTestSuiteByName(name string) *pkgTypes.TestSuite {
@@ -38,13 +78,49 @@ func GetTestSuiteByName(name string) *pkgTypes.TestSuite {
 }
 
 func GetTestCaseByName(name string) pkgTypes.TestCase {
+	return testCaseMap()[name]
+}
+
+// GetAllTestCases returns every built-in and registered test case, sorted by name.
+func GetAllTestCases() []pkgTypes.TestCase {
+	byName := testCaseMap()
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	testCases := make([]pkgTypes.TestCase, 0, len(names))
+	for _, name := range names {
+		testCases = append(testCases, byName[name])
+	}
+	return testCases
+}
+
+func testCaseMap() map[string]pkgTypes.TestCase {
 	testCaseMap := map[string]pkgTypes.TestCase{
-		"eth_getBalance":         NewBalanceTestCase(),
-		"eth_getCode":            NewCodeAtTestCase(),
-		"eth_call":               NewCallTestCase(),
-		"eth_estimateGas":        NewEstimateGasTestCase(),
-		"eth_sendRawTransaction": NewSendTransactionTestCase(),
+		"eth_getBalance":              NewBalanceTestCase(),
+		"eth_getCode":                 NewCodeAtTestCase(),
+		"eth_call":                    NewCallTestCase(),
+		"eth_estimateGas":             NewEstimateGasTestCase(),
+		"eth_sendRawTransaction":      NewSendTransactionTestCase(),
+		"eth_simulateV1":              NewSimulateTestCase(),
+		"eth_getLogs":                 NewLogsTestCase(),
+		"eth_feeHistory":              NewFeeMarketTestCase(),
+		"eth_getTransactionByHash":    NewTransactionLookupTestCase(),
+		"eth_getBlockByNumber":        NewBlockTestCase(),
+		"eth_getStorageAt":            NewStateTestCase(),
+		"eth_createAccessList":        NewAccessListTestCase(),
+		"debug_traceTransaction":      NewDebugTraceTestCase(),
+		"txpool_content":              NewTxPoolTestCase(),
+		"eth_sign":                    NewSigningTestCase(),
+		"web3_clientVersion":          NewBasicTestCase(),
+		"engine_exchangeCapabilities": NewEngineTestCase(),
+	}
+
+	for _, testCase := range registeredTestCases {
+		testCaseMap[testCase.Name()] = testCase
 	}
 
-	return testCaseMap[name]
+	return testCaseMap
 }
@@ -0,0 +1,21 @@
+package testcases
+
+import "testing"
+
+func TestSuitesCoverBuiltInTestCases(t *testing.T) {
+	inSuite := make(map[string]bool)
+	for _, suite := range GetAllTestSuites() {
+		for _, testCase := range suite.TestCases {
+			if testCase.RequiresContract() && !suite.RequiresContracts {
+				t.Errorf("%s requires contracts but suite %q does not deploy them", testCase.Name(), suite.Name)
+			}
+			inSuite[testCase.Name()] = true
+		}
+	}
+
+	for name := range testCaseMap() {
+		if !inSuite[name] {
+			t.Errorf("%s is not part of any suite, so a run without --tests skips it", name)
+		}
+	}
+}
@@ -51,16 +51,8 @@ func (t *SendTransactionTestCase) Execute(cfg config.Config) {
 	}
 	defer client.Close()
 
-	// Get all scenarios with config
-	scenarios := GetScenarios(cfg)
-
-	// Execute each scenario
-	for _, scenario := range scenarios {
-		if err := jsonrpc.SendTransaction(ctx, client, cfg, scenario); err != nil {
-			fmt.Printf("Error executing scenario %d (%s): %v\n", scenario.ID, scenario.Desc, err)
-		}
-		fmt.Println()
-	}
+	// Execute the scenarios, spread over the sender accounts when running in parallel
+	jsonrpc.SendTransactions(ctx, client, cfg, GetScenarios(cfg))
 }
 
 func (t *SendTransactionTestCase) corruptTransaction(signedTx *types.Transaction, params *pkgTypes.TxParams) *types.Transaction {
@@ -40,7 +40,7 @@ func GetScenarios(cfg config.Config) []pkgTypes.Scenario {
 		},
 		{
 			ID:     5,
-			Desc:   "NONCE_TOO_HIGH", // DOesnt work
+			Desc:   "NONCE_TOO_HIGH", // Most clients queue the tx instead of rejecting it, see TxPoolTestCase
 			Method: "eth_sendRawTransaction",
 			Modifiers: []pkgTypes.Modifier{
 				txbuilder.NonceModifier(0, func(current uint64) uint64 { return current + 100 }),
@@ -190,6 +190,64 @@ func GetScenarios(cfg config.Config) []pkgTypes.Scenario {
 			UseBatch: true,
 			PreSend:  createBatchTx(nil), // No options - sends identical transaction
 		},
+		{
+			ID:       23,
+			Desc:     "TXPOOL_ACCOUNT_SLOTS_EXCEEDED - 200 pending txs from one account",
+			Method:   "eth_sendRawTransaction",
+			Sequence: localOnly(createNonceSequence(200, 0)),
+		},
+		{
+			ID:       24,
+			Desc:     "TXPOOL_QUEUE_LIMIT - 64 queued txs behind a nonce gap",
+			Method:   "eth_sendRawTransaction",
+			Sequence: createNonceSequence(64, 1), // geth's default per-account queue limit is 64
+			Cleanup:  fillNonceGap,
+		},
+		{
+			ID:     25,
+			Desc:   "NONCE_TOO_DISTANT - nonce gap beyond the future nonce limit",
+			Method: "eth_sendRawTransaction",
+			Modifiers: []pkgTypes.Modifier{
+				txbuilder.NonceModifier(0, func(current uint64) uint64 { return current + 10_000 }),
+			},
+		},
+		{
+			ID:     26,
+			Desc:   "TXPOOL_FULL - pool filled past global capacity with low-fee txs",
+			Method: "eth_sendRawTransaction",
+			Modifiers: []pkgTypes.Modifier{
+				txbuilder.GasTipCapModifier(big.NewInt(10), nil), // besu --min-priority-fee
+			},
+			Sequence: localOnly(createNonceSequence(5_200, 0)), // geth's default global slots + queue is 5120
+		},
+		{
+			ID:       27,
+			Desc:     "REPLACEMENT - exactly the minimum 10% price bump",
+			Method:   "eth_sendRawTransaction",
+			Sequence: createReplacementSequence(10, 0),
+		},
+		{
+			ID:       28,
+			Desc:     "REPLACEMENT_TRANSACTION_UNDERPRICED - 1 wei below the minimum 10% price bump",
+			Method:   "eth_sendRawTransaction",
+			Sequence: createReplacementSequence(10, -1),
+		},
+		{
+			ID:       29,
+			Desc:     "REPLACEMENT - blob tx replaced by a non-blob tx",
+			Method:   "eth_sendRawTransaction",
+			Sequence: createBlobReplacementSequence(),
+		},
+		{
+			ID:     30,
+			Desc:   "NONCE_TOO_LOW - previous nonce",
+			Method: "eth_sendRawTransaction",
+			Modifiers: []pkgTypes.Modifier{
+				// Used by the sender's last transaction (deployments, or the one every parallel sender account
+				// sends during setup)
+				txbuilder.NonceModifier(0, func(current uint64) uint64 { return max(current, 1) - 1 }),
+			},
+		},
 
 		/*
 			// need to write revert opcode & invalid opcode in contracts
@@ -303,3 +361,117 @@ var createBatchTx = func(newValue *big.Int) pkgTypes.PreSendFunc {
 		return rawFirstTx, nil
 	}
 }
+
+// encodeTx builds, signs and encodes a transaction from params.
+func encodeTx(params *pkgTypes.TxParams) (string, error) {
+	signedTx, err := txbuilder.SignTransaction(txbuilder.BuildTransaction(params), params)
+	if err != nil {
+		return "", fmt.Errorf("error signing transaction: %w", err)
+	}
+
+	encodedTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("error encoding transaction: %w", err)
+	}
+
+	return "0x" + common.Bytes2Hex(encodedTx), nil
+}
+
+// localOnly skips sequences that are too expensive to run against a public network.
+var localOnly = func(sequence pkgTypes.SequenceFunc) pkgTypes.SequenceFunc {
+	return func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *pkgTypes.TxParams) ([]string, error) {
+		if !cfg.IsLocalNode() {
+			return nil, fmt.Errorf("skipped on %s, only runs against local nodes", cfg.Network)
+		}
+		return sequence(ctx, client, cfg, params)
+	}
+}
+
+// createNonceSequence signs count copies of the scenario tx with consecutive nonces starting at
+// the pending nonce + offset (an offset > 0 leaves a nonce gap), and moves the scenario tx to the next nonce.
+var createNonceSequence = func(count int, offset uint64) pkgTypes.SequenceFunc {
+	return func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *pkgTypes.TxParams) ([]string, error) {
+		base := params.Nonce + offset
+		rawTxs := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			seqParams := *params
+			seqParams.Nonce = base + uint64(i)
+			rawTx, err := encodeTx(&seqParams)
+			if err != nil {
+				return nil, err
+			}
+			rawTxs = append(rawTxs, rawTx)
+		}
+
+		params.Nonce = base + uint64(count)
+		return rawTxs, nil
+	}
+}
+
+// fillNonceGap signs the scenario tx at the pending nonce, which fills the gap below the txs a nonce gap sequence
+// queued. The queued txs are promoted, so the next scenario does not fill the gap itself and starts from a
+// contiguous nonce.
+var fillNonceGap pkgTypes.SequenceFunc = func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *pkgTypes.TxParams) ([]string, error) {
+	nonce, err := client.PendingNonceAt(ctx, params.FromAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending nonce: %w", err)
+	}
+
+	fillParams := *params
+	fillParams.Nonce = nonce
+	rawTx, err := encodeTx(&fillParams)
+	if err != nil {
+		return nil, err
+	}
+	return []string{rawTx}, nil
+}
+
+// createReplacementSequence submits the scenario tx as-is, then bumps the scenario tx fees by
+// bumpPercent plus offsetWei so that it replaces the first one at the same nonce.
+var createReplacementSequence = func(bumpPercent int64, offsetWei int64) pkgTypes.SequenceFunc {
+	bump := func(current *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(current, big.NewInt(100+bumpPercent))
+		bumped.Div(bumped, big.NewInt(100))
+		return bumped.Add(bumped, big.NewInt(offsetWei))
+	}
+
+	return func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *pkgTypes.TxParams) ([]string, error) {
+		rawTx, err := encodeTx(params)
+		if err != nil {
+			return nil, err
+		}
+
+		if params.IsDynamic {
+			params.GasTipCap = bump(params.GasTipCap)
+			params.GasFeeCap = bump(params.GasFeeCap)
+		} else {
+			params.GasPrice = bump(params.GasPrice)
+		}
+		return []string{rawTx}, nil
+	}
+}
+
+// createBlobReplacementSequence submits a blob tx at the scenario nonce and doubles the scenario tx fees,
+// so the scenario tx is a well-priced non-blob replacement of the blob tx.
+var createBlobReplacementSequence = func() pkgTypes.SequenceFunc {
+	return func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *pkgTypes.TxParams) ([]string, error) {
+		blobTx, err := txbuilder.BuildBlobTransaction(params, big.NewInt(1_000_000_000))
+		if err != nil {
+			return nil, err
+		}
+
+		signedBlobTx, err := txbuilder.SignTransaction(blobTx, params)
+		if err != nil {
+			return nil, fmt.Errorf("error signing blob transaction: %w", err)
+		}
+
+		encodedBlobTx, err := signedBlobTx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding blob transaction: %w", err)
+		}
+
+		params.GasTipCap = new(big.Int).Mul(params.GasTipCap, big.NewInt(2))
+		params.GasFeeCap = new(big.Int).Mul(params.GasFeeCap, big.NewInt(2))
+		return []string{"0x" + common.Bytes2Hex(encodedBlobTx)}, nil
+	}
+}
@@ -0,0 +1,291 @@
+package testcases
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/contract"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// SigningTestCase covers node-side signing (eth_sign, eth_signTransaction, eth_sendTransaction and
+// the personal_ namespace) with the unlocked dev account. Clients that disable these methods differ
+// in the code they return (-32601 vs -32000 vs custom).
+type SigningTestCase struct {
+	lockedAccount string // node-managed account without an unlock, set by Execute
+}
+
+func (t *SigningTestCase) Name() string {
+	return "eth_sign"
+}
+
+func (t *SigningTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *SigningTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	// Remote networks have no node-managed accounts, so every request exercises the unknown account path
+	devAccount := cfg.DevAccount
+	if devAccount == "" {
+		devAccount = cfg.From
+	}
+	lockedAccount := t.lockedAccount
+	if lockedAccount == "" {
+		lockedAccount = cfg.From
+	}
+	store := inputHex(contract.Storage, "store", big.NewInt(42))
+
+	return []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_sign",
+				Params:  []interface{}{devAccount, "0xdeadbeef"},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_sign",
+				Params:  []interface{}{cfg.InvalidContract, "0xdeadbeef"},
+			},
+			Desc: "Unknown account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_sign",
+				Params:  []interface{}{lockedAccount, "0xdeadbeef"},
+			},
+			Desc: "Locked account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_sign",
+				Params:  []interface{}{"0x1234", "0xdeadbeef"},
+			},
+			Desc: "Invalid address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_sign",
+				Params:  []interface{}{devAccount, "hello"},
+			},
+			Desc: "Non-hex data",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_sign",
+				Params:  []interface{}{devAccount},
+			},
+			Desc: "Missing data",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_sign",
+				Params:  []interface{}{"0xdeadbeef", devAccount},
+			},
+			Desc: "Swapped params (personal_sign order)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store}},
+			},
+			Desc: "eth_signTransaction proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store}},
+			},
+			Desc: "eth_signTransaction missing from",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.ToContract, "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store}},
+			},
+			Desc: "eth_signTransaction missing gas",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "data": store}},
+			},
+			Desc: "eth_signTransaction missing nonce",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store, "chainId": "0x1"}},
+			},
+			Desc: "eth_signTransaction mismatched chainId",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": cfg.InvalidContract, "to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store}},
+			},
+			Desc: "eth_signTransaction unknown account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": lockedAccount, "to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store}},
+			},
+			Desc: "eth_signTransaction locked account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_signTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.ToContract, "gas": "0x186a0", "gasPrice": "0x3b9aca00", "nonce": "0x0", "data": store, "maxFeePerGas": "0x3b9aca00"}},
+			},
+			Desc: "eth_signTransaction both gasPrice and maxFeePerGas",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.From, "value": "0x1"}},
+			},
+			Desc: "eth_sendTransaction proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"to": cfg.From, "value": "0x1"}},
+			},
+			Desc: "eth_sendTransaction missing from",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.From, "value": "0x1", "chainId": "0x1"}},
+			},
+			Desc: "eth_sendTransaction mismatched chainId",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"from": cfg.InvalidContract, "to": cfg.From, "value": "0x1"}},
+			},
+			Desc: "eth_sendTransaction unknown account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"from": lockedAccount, "to": cfg.From, "value": "0x1"}},
+			},
+			Desc: "eth_sendTransaction locked account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": "0x1234", "value": "0x1"}},
+			},
+			Desc: "eth_sendTransaction invalid to address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "eth_sendTransaction",
+				Params:  []interface{}{map[string]string{"from": devAccount, "to": cfg.From, "value": "0xffffffffffffffffffffffffffffffffffffffff"}},
+			},
+			Desc: "eth_sendTransaction insufficient funds",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "personal_sign",
+				Params:  []interface{}{"0xdeadbeef", devAccount, ""},
+			},
+			Desc: "personal_sign proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "personal_unlockAccount",
+				Params:  []interface{}{devAccount, "", 0},
+			},
+			Desc: "personal_unlockAccount dev account",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "personal_listAccounts",
+				Params:  []interface{}{},
+			},
+			Desc: "personal_listAccounts proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      26,
+				Method:  "eth_signTypedData_v4",
+				Params:  []interface{}{devAccount, `{"types":{},"primaryType":"Mail","domain":{},"message":{}}`},
+			},
+			Desc: "eth_signTypedData_v4 malformed typed data",
+		},
+	}
+}
+
+func (t *SigningTestCase) Execute(cfg config.Config) {
+	var account string
+	if err := rpcResult(cfg, "personal_newAccount", []interface{}{"password"}, &account); err != nil {
+		fmt.Println("Could not create a locked account, falling back to the test account:", err)
+	}
+	t.lockedAccount = account
+
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewSigningTestCase() pkgTypes.TestCase {
+	return &SigningTestCase{}
+}
@@ -0,0 +1,482 @@
+package testcases
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/contract"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// SimulateTestCase covers eth_simulateV1, whose error codes are specified in
+// https://github.com/ethereum/execution-apis/blob/main/src/eth/execute.yaml
+type SimulateTestCase struct{}
+
+func (t *SimulateTestCase) Name() string {
+	return "eth_simulateV1"
+}
+
+func (t *SimulateTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *SimulateTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	storage := cfg.DeployedContracts["storage"].Hex()
+	opcodes := cfg.DeployedContracts["opcodes"].Hex()
+	store := inputHex(contract.Storage, "store", big.NewInt(42))
+	retrieve := inputHex(contract.Storage, "retrieve")
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": storage, "data": store},
+									map[string]string{"from": cfg.From, "to": storage, "data": retrieve},
+								},
+							},
+						},
+						"validation":     true,
+						"traceTransfers": true,
+					},
+					"latest",
+				},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": storage, "data": store, "nonce": "0x0"},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "NONCE_TOO_LOW (-38010)",
+			ExpectedCode: -38010,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": storage, "data": store, "nonce": "0xffff"},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "NONCE_TOO_HIGH (-38011)",
+			ExpectedCode: -38011,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"baseFeePerGas": "0x3b9aca00"},
+								"calls": []interface{}{
+									map[string]string{
+										"from":                 cfg.From,
+										"to":                   storage,
+										"data":                 store,
+										"maxFeePerGas":         "0x1",
+										"maxPriorityFeePerGas": "0x1",
+									},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "BASE_FEE_TOO_LOW (-38012)",
+			ExpectedCode: -38012,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": storage, "data": store, "gas": "0x1"},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "INTRINSIC_GAS_TOO_LOW (-38013)",
+			ExpectedCode: -38013,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{
+										"from":  cfg.From,
+										"to":    cfg.InvalidContract,
+										"value": "0xffffffffffffffffffffffffffffffffffffffff",
+									},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "INSUFFICIENT_FUNDS (-38014)",
+			ExpectedCode: -38014,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"gasLimit": "0x5208"},
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": storage, "data": store, "gas": "0x100000"},
+								},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc:         "BLOCK_GAS_LIMIT_EXCEEDED (-38015)",
+			ExpectedCode: -38015,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"number": "0xfffffe"},
+							},
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"number": "0xfffffd"},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc:         "BLOCK_NUMBER_NOT_INCREASING (-38020)",
+			ExpectedCode: -38020,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"time": "0xfffffffe"},
+							},
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"time": "0xfffffffd"},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc:         "BLOCK_TIMESTAMP_NOT_INCREASING (-38021)",
+			ExpectedCode: -38021,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"stateOverrides": map[string]interface{}{
+									"0x0000000000000000000000000000000000000001": map[string]string{
+										"movePrecompileToAddress": "0x0000000000000000000000000000000000000001",
+									},
+								},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc:         "MOVE_PRECOMPILE_SELF_REFERENCE (-38022)",
+			ExpectedCode: -38022,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"stateOverrides": map[string]interface{}{
+									"0x0000000000000000000000000000000000000001": map[string]string{
+										"movePrecompileToAddress": "0x0000000000000000000000000000000000000123",
+									},
+									"0x0000000000000000000000000000000000000002": map[string]string{
+										"movePrecompileToAddress": "0x0000000000000000000000000000000000000123",
+									},
+								},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc:         "MOVE_PRECOMPILE_DUPLICATE_TARGET (-38023)",
+			ExpectedCode: -38023,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": storage, "to": storage, "data": retrieve},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "SENDER_IS_NOT_EOA (-38024)",
+			ExpectedCode: -38024,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{
+										"from": cfg.From,
+										// Contract creation with init code one byte above the EIP-3860 limit (49152)
+										"data": "0x" + strings.Repeat("00", 49153),
+										"gas":  "0x1c9c380",
+									},
+								},
+							},
+						},
+						"validation": true,
+					},
+					"latest",
+				},
+			},
+			Desc:         "MAX_INIT_CODE_SIZE_EXCEEDED (-38025)",
+			ExpectedCode: -38025,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"blockOverrides": map[string]string{"number": "0xffffffff"},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc:         "CLIENT_LIMIT_EXCEEDED (-38026) - block number far ahead of head",
+			ExpectedCode: -38026,
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": opcodes, "data": inputHex(contract.OpCodes, "test_invalid")},
+								},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			// Call failures are reported in the call's result, not as a JSON-RPC error, so there is no code to assert
+			Desc: "VM_ERROR (-32015) - INVALID opcode",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": opcodes, "data": inputHex(contract.OpCodes, "test_revert")},
+								},
+							},
+						},
+					},
+					"latest",
+				},
+			},
+			Desc: "EXECUTION_REVERTED (3) - REVERT opcode",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{
+							map[string]interface{}{
+								"calls": []interface{}{
+									map[string]string{"from": cfg.From, "to": storage, "data": store},
+								},
+							},
+						},
+					},
+					"unsupported",
+				},
+			},
+			Desc: "Invalid block parameter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": map[string]string{"calls": "0x"},
+					},
+					"latest",
+				},
+			},
+			Desc: "Invalid blockStateCalls type",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_simulateV1",
+				Params:  []interface{}{},
+			},
+			Desc: "Missing params",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_simulateV1",
+				Params: []interface{}{
+					map[string]interface{}{
+						"blockStateCalls": []interface{}{},
+					},
+					"latest",
+				},
+			},
+			Desc: "Empty blockStateCalls",
+		},
+	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_simulateV1", 100, func(block interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"blockStateCalls": []interface{}{
+					map[string]interface{}{
+						"calls": []interface{}{
+							map[string]string{"from": cfg.From, "to": storage, "data": retrieve},
+						},
+					},
+				},
+			},
+			block,
+		}
+	})...)
+}
+
+func (t *SimulateTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewSimulateTestCase() pkgTypes.TestCase {
+	return &SimulateTestCase{}
+}
@@ -0,0 +1,107 @@
+package testcases
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	"github.com/eth-error-tests/pkg/mockrpc"
+)
+
+// captureStdout returns everything fn prints to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestSimulateExpectedCodes(t *testing.T) {
+	// The eth_simulateV1 error codes of the execution-apis spec, by request id
+	specCodes := map[int]int{
+		2:  -38010, // NONCE_TOO_LOW
+		3:  -38011, // NONCE_TOO_HIGH
+		4:  -38012, // BASE_FEE_TOO_LOW
+		5:  -38013, // INTRINSIC_GAS_TOO_LOW
+		6:  -38014, // INSUFFICIENT_FUNDS
+		7:  -38015, // BLOCK_GAS_LIMIT_EXCEEDED
+		8:  -38020, // BLOCK_NUMBER_NOT_INCREASING
+		9:  -38021, // BLOCK_TIMESTAMP_NOT_INCREASING
+		10: -38022, // MOVE_PRECOMPILE_SELF_REFERENCE
+		11: -38023, // MOVE_PRECOMPILE_DUPLICATE_TARGET
+		12: -38024, // SENDER_IS_NOT_EOA
+		13: -38025, // MAX_INIT_CODE_SIZE_EXCEEDED
+		14: -38026, // CLIENT_LIMIT_EXCEEDED
+	}
+
+	for _, offset := range []int{0, 1} {
+		server := mockrpc.NewServer(mockrpc.Profile{})
+		server.HandleDevChain(1337)
+		server.Handle("eth_simulateV1", func(req mockrpc.Request) (interface{}, *mockrpc.Error) {
+			var id int
+			if err := json.Unmarshal(req.Id, &id); err != nil {
+				return nil, &mockrpc.Error{Code: -32600, Message: "invalid id"}
+			}
+			if code, ok := specCodes[id]; ok {
+				return nil, &mockrpc.Error{Code: code + offset, Message: "simulation failed"}
+			}
+			return []interface{}{}, nil
+		})
+		url, err := server.Start("127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg := config.Config{Url: url, ChainID: 1337}
+
+		requests := NewSimulateTestCase().GetRequests(cfg)
+		output := captureStdout(t, func() { jsonrpc.SendReq(requests, cfg) })
+		server.Close()
+
+		responses := make(map[string]string)
+		var desc string
+		for _, line := range strings.Split(output, "\n") {
+			if rest, ok := strings.CutPrefix(line, "Scenario: "); ok {
+				desc, _, _ = strings.Cut(rest, "  - Request:")
+			} else if strings.HasPrefix(line, "Response: ") {
+				responses[desc] = line
+			}
+		}
+
+		for _, request := range requests {
+			code, ok := specCodes[request.Id]
+			if !ok {
+				// Call failures are reported in the call's result
+				if strings.Contains(responses[request.Desc], "Expected code") {
+					t.Errorf("%s: unexpected code check: %s", request.Desc, responses[request.Desc])
+				}
+				continue
+			}
+			want := fmt.Sprintf("Expected code %d: PASS", code)
+			if offset != 0 {
+				want = fmt.Sprintf("Expected code %d: FAIL (got %d)", code, code+offset)
+			}
+			if !strings.Contains(responses[request.Desc], want) {
+				t.Errorf("%s: response %q, want %q", request.Desc, responses[request.Desc], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package testcases
+
+import (
+	"fmt"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// numberSlot is the storage slot of the Storage contract's `number` variable
+const numberSlot = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+// stateWindow is the largest default number of recent blocks whose state the clients keep (besu's
+// --bonsai-historical-block-limit; geth keeps 128), so older blocks are outside the proof window
+const stateWindow = 512
+
+// StateTestCase covers state reads (eth_getStorageAt, eth_getProof and eth_getTransactionCount)
+// using the Storage contract's `number` slot as the known-good target.
+type StateTestCase struct{}
+
+func (t *StateTestCase) Name() string {
+	return "eth_getStorageAt"
+}
+
+func (t *StateTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *StateTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	storage := cfg.ToContract
+
+	// The historical block must be older than the proof window
+	var skipped []int
+	var historicalBlock string
+	if head, err := headNumber(cfg); err != nil {
+		fmt.Printf("Warning: skipping the eth_getProof historical block scenario: %v\n", err)
+		skipped = append(skipped, 18)
+	} else if head <= stateWindow {
+		fmt.Printf("Warning: skipping the eth_getProof historical block scenario: the chain has %d blocks, the proof window is %d\n", head+1, stateWindow)
+		skipped = append(skipped, 18)
+	} else {
+		historicalBlock = hexutil.EncodeUint64(head - stateWindow - 1)
+	}
+
+	manyKeys := make([]interface{}, 1025)
+	for i := range manyKeys {
+		manyKeys[i] = fmt.Sprintf("0x%064x", i)
+	}
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, numberSlot, "latest"},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, "0x0", "latest"},
+			},
+			Desc: "Unpadded slot key",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, "0x00", "latest"},
+			},
+			Desc: "Slot key with leading zero",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, numberSlot + "00", "latest"},
+			},
+			Desc: "Slot key too long (33 bytes)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, "0xzz", "latest"},
+			},
+			Desc: "Non-hex slot key",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, numberSlot[2:], "latest"},
+			},
+			Desc: "Slot key without 0x prefix",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, 0, "latest"},
+			},
+			Desc: "Slot key as integer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{"0x1234", numberSlot, "latest"},
+			},
+			Desc: "Invalid address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage, numberSlot},
+			},
+			Desc: "Missing block parameter",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_getStorageAt",
+				Params:  []interface{}{storage},
+			},
+			Desc: "Missing slot key",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{numberSlot}, "latest"},
+			},
+			Desc: "eth_getProof proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{}, "latest"},
+			},
+			Desc: "eth_getProof no keys",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, manyKeys, "latest"},
+			},
+			Desc: "eth_getProof too many keys (1025)",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{"0xzz"}, "latest"},
+			},
+			Desc: "eth_getProof non-hex key",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{numberSlot + "00"}, "latest"},
+			},
+			Desc: "eth_getProof key too long",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, numberSlot, "latest"},
+			},
+			Desc: "eth_getProof keys not an array",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_getProof",
+				Params:  []interface{}{"0x1234", []interface{}{numberSlot}, "latest"},
+			},
+			Desc: "eth_getProof invalid address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{numberSlot}, historicalBlock},
+			},
+			Desc: "eth_getProof historical block beyond proof window",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{numberSlot}, "earliest"},
+			},
+			Desc: "eth_getProof earliest block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_getProof",
+				Params:  []interface{}{storage, []interface{}{numberSlot}, "0xffffffff"},
+			},
+			Desc: "eth_getProof future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "eth_getTransactionCount",
+				Params:  []interface{}{cfg.From, "latest"},
+			},
+			Desc: "eth_getTransactionCount proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "eth_getTransactionCount",
+				Params:  []interface{}{"0x1234", "latest"},
+			},
+			Desc: "eth_getTransactionCount invalid address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "eth_getTransactionCount",
+				Params:  []interface{}{cfg.From[2:], "latest"},
+			},
+			Desc: "eth_getTransactionCount address without 0x prefix",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "eth_getTransactionCount",
+				Params:  []interface{}{0xabcdef, "latest"},
+			},
+			Desc: "eth_getTransactionCount address as integer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "eth_getTransactionCount",
+				Params:  []interface{}{cfg.From},
+			},
+			Desc: "eth_getTransactionCount missing block parameter",
+		},
+	}
+
+	requests = append(requests, BlockParamRequests(cfg, "eth_getStorageAt", 100, func(block interface{}) []interface{} {
+		return []interface{}{storage, numberSlot, block}
+	})...)
+	requests = append(requests, BlockParamRequests(cfg, "eth_getTransactionCount", 200, func(block interface{}) []interface{} {
+		return []interface{}{cfg.From, block}
+	})...)
+	requests = append(requests, BlockParamRequests(cfg, "eth_getProof", 300, func(block interface{}) []interface{} {
+		return []interface{}{storage, []interface{}{numberSlot}, block}
+	})...)
+	return withoutIDs(requests, skipped...)
+}
+
+func (t *StateTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewStateTestCase() pkgTypes.TestCase {
+	return &StateTestCase{}
+}
@@ -0,0 +1,301 @@
+package testcases
+
+import (
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// unknownTxHash is a well-formed hash that does not belong to any transaction
+const unknownTxHash = "0x2222222222222222222222222222222222222222222222222222222222222222"
+
+// TransactionLookupTestCase covers transaction and receipt lookups by hash, block and index.
+// The happy path uses the runner's own Storage deployment so the transaction is always real.
+type TransactionLookupTestCase struct{}
+
+func (t *TransactionLookupTestCase) Name() string {
+	return "eth_getTransactionByHash"
+}
+
+func (t *TransactionLookupTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *TransactionLookupTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	tx := knownTransaction(cfg)
+
+	requests := []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "eth_getTransactionByHash",
+				Params:  []interface{}{tx.Hash},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "eth_getTransactionByHash",
+				Params:  []interface{}{unknownTxHash},
+			},
+			Desc: "Unknown transaction hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "eth_getTransactionByHash",
+				Params:  []interface{}{"0x1234"},
+			},
+			Desc: "Transaction hash wrong length",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "eth_getTransactionByHash",
+				Params:  []interface{}{unknownTxHash[2:]},
+			},
+			Desc: "Transaction hash without 0x prefix",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "eth_getTransactionByHash",
+				Params:  []interface{}{},
+			},
+			Desc: "Missing transaction hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "eth_getTransactionReceipt",
+				Params:  []interface{}{tx.Hash},
+			},
+			Desc: "eth_getTransactionReceipt proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "eth_getTransactionReceipt",
+				Params:  []interface{}{unknownTxHash},
+			},
+			Desc: "eth_getTransactionReceipt unknown hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "eth_getTransactionReceipt",
+				Params:  []interface{}{"0x1234"},
+			},
+			Desc: "eth_getTransactionReceipt hash wrong length",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "eth_getTransactionByBlockNumberAndIndex",
+				Params:  []interface{}{tx.BlockNumber, tx.TransactionIndex},
+			},
+			Desc: "eth_getTransactionByBlockNumberAndIndex proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "eth_getTransactionByBlockNumberAndIndex",
+				Params:  []interface{}{tx.BlockNumber, "0xffff"},
+			},
+			Desc: "eth_getTransactionByBlockNumberAndIndex index out of range",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_getTransactionByBlockNumberAndIndex",
+				Params:  []interface{}{tx.BlockNumber, 0},
+			},
+			Desc: "eth_getTransactionByBlockNumberAndIndex index as integer",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_getTransactionByBlockNumberAndIndex",
+				Params:  []interface{}{"pending", "0x0"},
+			},
+			Desc: "eth_getTransactionByBlockNumberAndIndex pending block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_getTransactionByBlockNumberAndIndex",
+				Params:  []interface{}{"0x1", "0x0"},
+			},
+			Desc: "eth_getTransactionByBlockNumberAndIndex early (possibly pruned) block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_getTransactionByBlockNumberAndIndex",
+				Params:  []interface{}{"0xffffffff", "0x0"},
+			},
+			Desc: "eth_getTransactionByBlockNumberAndIndex future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_getTransactionByBlockHashAndIndex",
+				Params:  []interface{}{tx.BlockHash, tx.TransactionIndex},
+			},
+			Desc: "eth_getTransactionByBlockHashAndIndex proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      16,
+				Method:  "eth_getTransactionByBlockHashAndIndex",
+				Params:  []interface{}{tx.BlockHash, "0xffff"},
+			},
+			Desc: "eth_getTransactionByBlockHashAndIndex index out of range",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      17,
+				Method:  "eth_getTransactionByBlockHashAndIndex",
+				Params:  []interface{}{unknownBlockHash, "0x0"},
+			},
+			Desc: "eth_getTransactionByBlockHashAndIndex unknown block hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      18,
+				Method:  "eth_getTransactionByBlockHashAndIndex",
+				Params:  []interface{}{"0x1234", "0x0"},
+			},
+			Desc: "eth_getTransactionByBlockHashAndIndex block hash wrong length",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      19,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{tx.BlockNumber},
+			},
+			Desc: "eth_getBlockReceipts proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      20,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{tx.BlockHash},
+			},
+			Desc: "eth_getBlockReceipts by block hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      21,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{"pending"},
+			},
+			Desc: "eth_getBlockReceipts pending block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      22,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{"0x1"},
+			},
+			Desc: "eth_getBlockReceipts early (possibly pruned) block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      23,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{"0xffffffff"},
+			},
+			Desc: "eth_getBlockReceipts future block",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      24,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{unknownBlockHash},
+			},
+			Desc: "eth_getBlockReceipts unknown block hash",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      25,
+				Method:  "eth_getBlockReceipts",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_getBlockReceipts missing block",
+		},
+	}
+
+	return append(requests, BlockParamRequests(cfg, "eth_getBlockReceipts", 100, func(block interface{}) []interface{} {
+		return []interface{}{block}
+	})...)
+}
+
+func (t *TransactionLookupTestCase) Execute(cfg config.Config) {
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewTransactionLookupTestCase() pkgTypes.TestCase {
+	return &TransactionLookupTestCase{}
+}
+
+type txLocation struct {
+	Hash             string `json:"transactionHash"`
+	BlockNumber      string `json:"blockNumber"`
+	BlockHash        string `json:"blockHash"`
+	TransactionIndex string `json:"transactionIndex"`
+}
+
+// knownTransaction locates the Storage deployment transaction. When the contracts were not deployed by
+// this run (e.g. sepolia uses pre-deployed contracts), the first transaction of the latest block is used instead.
+func knownTransaction(cfg config.Config) txLocation {
+	if txHash, ok := cfg.DeploymentTxs["storage"]; ok {
+		var receipt txLocation
+		if rpcResult(cfg, "eth_getTransactionReceipt", []interface{}{txHash.Hex()}, &receipt) == nil {
+			return receipt
+		}
+	}
+
+	var block struct {
+		Number       string   `json:"number"`
+		Hash         string   `json:"hash"`
+		Transactions []string `json:"transactions"`
+	}
+	if err := rpcResult(cfg, "eth_getBlockByNumber", []interface{}{"latest", false}, &block); err != nil || len(block.Transactions) == 0 {
+		return txLocation{Hash: unknownTxHash, BlockNumber: "latest", BlockHash: unknownBlockHash, TransactionIndex: "0x0"}
+	}
+
+	return txLocation{
+		Hash:             block.Transactions[0],
+		BlockNumber:      block.Number,
+		BlockHash:        block.Hash,
+		TransactionIndex: "0x0",
+	}
+}
@@ -0,0 +1,196 @@
+package testcases
+
+import (
+	"fmt"
+
+	"github.com/eth-error-tests/pkg/config"
+	"github.com/eth-error-tests/pkg/jsonrpc"
+	pkgTypes "github.com/eth-error-tests/pkg/types"
+)
+
+// queuedNonceGap keeps the queued tx clear of the nonces the transaction scenarios queue (NONCE_TOO_HIGH uses the
+// pending nonce + 100) and below besu's default future nonce limit of 200 (--tx-pool-max-future-by-sender)
+const queuedNonceGap = 150
+
+// TxPoolTestCase covers mempool introspection. Execute first places a nonce-gap transaction in the
+// pool, so every client has at least one known queued transaction to expose (or reject).
+type TxPoolTestCase struct {
+	queuedTx string // hash of the nonce-gap tx, set by Execute
+}
+
+func (t *TxPoolTestCase) Name() string {
+	return "txpool_content"
+}
+
+func (t *TxPoolTestCase) RequiresContract() bool {
+	return true
+}
+
+func (t *TxPoolTestCase) GetRequests(cfg config.Config) []pkgTypes.Meta {
+	queuedTx := t.queuedTx
+	if queuedTx == "" {
+		queuedTx = unknownTxHash
+	}
+
+	return []pkgTypes.Meta{
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      1,
+				Method:  "txpool_status",
+				Params:  []interface{}{},
+			},
+			Desc: "Proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      2,
+				Method:  "txpool_status",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "txpool_status unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      3,
+				Method:  "txpool_content",
+				Params:  []interface{}{},
+			},
+			Desc: "txpool_content proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      4,
+				Method:  "txpool_contentFrom",
+				Params:  []interface{}{cfg.From},
+			},
+			Desc: "txpool_contentFrom sender with queued tx",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      5,
+				Method:  "txpool_contentFrom",
+				Params:  []interface{}{cfg.InvalidContract},
+			},
+			Desc: "txpool_contentFrom address without txs",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      6,
+				Method:  "txpool_contentFrom",
+				Params:  []interface{}{"0x1234"},
+			},
+			Desc: "txpool_contentFrom invalid address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      7,
+				Method:  "txpool_contentFrom",
+				Params:  []interface{}{},
+			},
+			Desc: "txpool_contentFrom missing address",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      8,
+				Method:  "txpool_inspect",
+				Params:  []interface{}{},
+			},
+			Desc: "txpool_inspect proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      9,
+				Method:  "txpool_besuTransactions",
+				Params:  []interface{}{},
+			},
+			Desc: "txpool_besuTransactions proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      10,
+				Method:  "txpool_besuStatistics",
+				Params:  []interface{}{},
+			},
+			Desc: "txpool_besuStatistics proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      11,
+				Method:  "eth_pendingTransactions",
+				Params:  []interface{}{},
+			},
+			Desc: "eth_pendingTransactions proper request",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      12,
+				Method:  "eth_pendingTransactions",
+				Params:  []interface{}{"latest"},
+			},
+			Desc: "eth_pendingTransactions unexpected param",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      13,
+				Method:  "eth_getTransactionByHash",
+				Params:  []interface{}{queuedTx},
+			},
+			Desc: "eth_getTransactionByHash queued tx",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      14,
+				Method:  "eth_getTransactionReceipt",
+				Params:  []interface{}{queuedTx},
+			},
+			Desc: "eth_getTransactionReceipt queued tx",
+		},
+		{
+			JsonRpcRequest: pkgTypes.JsonRpcRequest{
+				JsonRpc: "2.0",
+				Id:      15,
+				Method:  "eth_getTransactionCount",
+				Params:  []interface{}{cfg.From, "pending"},
+			},
+			Desc: "eth_getTransactionCount pending ignores queued tx",
+		},
+	}
+}
+
+func (t *TxPoolTestCase) Execute(cfg config.Config) {
+	client, tx, err := sendTx(cfg, jsonrpc.NonceModifier(0, func(current uint64) uint64 { return current + queuedNonceGap }))
+	if err != nil {
+		fmt.Println("Error sending nonce-gap transaction:", err)
+	} else {
+		client.Close()
+		t.queuedTx = tx.Hash().Hex()
+		fmt.Printf("Nonce-gap transaction sent: %s (nonce %d)\n", t.queuedTx, tx.Nonce())
+
+		var content map[string]map[string]interface{}
+		if err := rpcResult(cfg, "txpool_contentFrom", []interface{}{cfg.From}, &content); err == nil {
+			_, queued := content["queued"][fmt.Sprint(tx.Nonce())]
+			fmt.Printf("Nonce-gap transaction queued: %t\n", queued)
+		}
+	}
+
+	requests := t.GetRequests(cfg)
+	jsonrpc.SendReq(requests, cfg)
+}
+
+func NewTxPoolTestCase() pkgTypes.TestCase {
+	return &TxPoolTestCase{}
+}
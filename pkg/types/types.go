@@ -53,8 +53,10 @@ type Scenario struct {
 	Desc      string
 	Method    string
 	Modifiers []Modifier
-	PreSend   PreSendFunc // Returns first raw tx for batch
-	UseBatch  bool        // If true, PreSend should return a raw transaction to send in batch
+	PreSend   PreSendFunc  // Returns first raw tx for batch
+	UseBatch  bool         // If true, PreSend should return a raw transaction to send in batch
+	Sequence  SequenceFunc // Returns raw txs to submit before the scenario tx, for multi-transaction scenarios
+	Cleanup   SequenceFunc // Returns raw txs to submit after the scenario tx, e.g. to fill a nonce gap it left
 }
 
 type TxParams struct {
@@ -78,9 +80,16 @@ type Modifier func(ctx context.Context, client *ethclient.Client, params *TxPara
 // PreSendFunc is a function that executes before sending a transaction, typically for batch scenarios.
 type PreSendFunc func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *TxParams) (string, error)
 
+// SequenceFunc builds raw transactions that are submitted ahead of the scenario transaction.
+// It may adjust params (e.g. nonce or fees) for the scenario transaction that follows the sequence.
+type SequenceFunc func(ctx context.Context, client *ethclient.Client, cfg config.Config, params *TxParams) ([]string, error)
+
 type Meta struct {
-	JsonRpcRequest `json:"jsonrpc"`
-	Desc           string `json:"desc"`
+	JsonRpcRequest  `json:"jsonrpc"`
+	Desc            string            `json:"desc"`
+	Headers         map[string]string `json:"-"` // Extra HTTP headers, e.g. Authorization for the Engine API
+	ExpectedCode    int               `json:"-"` // Error code the spec mandates for this scenario, 0 if unspecified
+	ExpectedMessage string            `json:"-"` // Substring the error message must contain, empty if unspecified
 }
 
 type JsonRpcRequest struct {